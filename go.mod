@@ -1,5 +1,5 @@
 module go.devnw.com/ds
 
-go 1.21
+go 1.23
 
 require github.com/google/go-cmp v0.6.0
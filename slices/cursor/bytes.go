@@ -0,0 +1,245 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Bytes is a byte cursor for decoding and encoding binary data such as
+// wire protocols. The Read methods decode a value from the cursor and move
+// past it, while the Write methods encode a value at the cursor, inserting
+// it before the element there, and move past it.
+//
+// After a value is read from the end of the buffer the cursor rests on the
+// end of the buffer, where further reads return ErrUnderflow and writes
+// append to the buffer. A read which fails leaves the cursor unchanged.
+type Bytes struct {
+	*Cursor[byte]
+
+	order binary.ByteOrder
+}
+
+// NewBytes creates a new Bytes cursor over the data which decodes and
+// encodes fixed size values in the given byte order.
+func NewBytes(data []byte, order binary.ByteOrder, opts ...Option[byte]) *Bytes {
+	return &Bytes{
+		Cursor: New(data, opts...),
+		order:  order,
+	}
+}
+
+// read returns the next n bytes and moves the cursor past them
+func (b *Bytes) read(n int) ([]byte, error) {
+	if n < 0 || b.pos+n > b.store().Len() {
+		return nil, ErrUnderflow
+	}
+
+	out := b.store().Slice(b.pos, b.pos+n)
+	b.move(b.pos + n)
+
+	return out, nil
+}
+
+// write inserts the bytes at the cursor and moves the cursor past them
+func (b *Bytes) write(p []byte) error {
+	var err error
+	if b.pos >= b.store().Len() {
+		err = b.Append(p...)
+	} else {
+		err = b.Insert(p...)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	b.move(b.pos + len(p))
+	return nil
+}
+
+// ReadBytes reads the next n bytes
+func (b *Bytes) ReadBytes(n int) ([]byte, error) {
+	return b.read(n)
+}
+
+// ReadUint8 reads a single byte
+func (b *Bytes) ReadUint8() (uint8, error) {
+	p, err := b.read(1)
+	if err != nil {
+		return 0, err
+	}
+
+	return p[0], nil
+}
+
+// ReadUint16 reads a 16 bit unsigned integer
+func (b *Bytes) ReadUint16() (uint16, error) {
+	p, err := b.read(2)
+	if err != nil {
+		return 0, err
+	}
+
+	return b.order.Uint16(p), nil
+}
+
+// ReadUint32 reads a 32 bit unsigned integer
+func (b *Bytes) ReadUint32() (uint32, error) {
+	p, err := b.read(4)
+	if err != nil {
+		return 0, err
+	}
+
+	return b.order.Uint32(p), nil
+}
+
+// ReadUint64 reads a 64 bit unsigned integer
+func (b *Bytes) ReadUint64() (uint64, error) {
+	p, err := b.read(8)
+	if err != nil {
+		return 0, err
+	}
+
+	return b.order.Uint64(p), nil
+}
+
+// ReadFloat32 reads an IEEE 754 single precision float
+func (b *Bytes) ReadFloat32() (float32, error) {
+	v, err := b.ReadUint32()
+	return math.Float32frombits(v), err
+}
+
+// ReadFloat64 reads an IEEE 754 double precision float
+func (b *Bytes) ReadFloat64() (float64, error) {
+	v, err := b.ReadUint64()
+	return math.Float64frombits(v), err
+}
+
+// ReadUvarint reads an unsigned integer in the varint encoding used by
+// encoding/binary. It returns ErrOverflow if the value does not fit in 64
+// bits.
+func (b *Bytes) ReadUvarint() (uint64, error) {
+	end := min(b.pos+binary.MaxVarintLen64, b.store().Len())
+	if b.pos >= end {
+		return 0, ErrUnderflow
+	}
+
+	v, n := binary.Uvarint(b.store().Slice(b.pos, end))
+	switch {
+	case n == 0:
+		return 0, ErrUnderflow
+	case n < 0:
+		return 0, ErrOverflow
+	}
+
+	b.move(b.pos + n)
+	return v, nil
+}
+
+// ReadVarint reads a signed integer in the zig-zag varint encoding used by
+// encoding/binary. It returns ErrOverflow if the value does not fit in 64
+// bits.
+func (b *Bytes) ReadVarint() (int64, error) {
+	ux, err := b.ReadUvarint()
+	if err != nil {
+		return 0, err
+	}
+
+	v := int64(ux >> 1)
+	if ux&1 != 0 {
+		v = ^v
+	}
+
+	return v, nil
+}
+
+// ReadString reads a string prefixed by its length in bytes as a uvarint
+func (b *Bytes) ReadString() (string, error) {
+	start := b.pos
+
+	n, err := b.ReadUvarint()
+	if err != nil {
+		return "", err
+	}
+
+	if n > uint64(b.store().Len()-b.pos) {
+		b.move(start)
+		return "", ErrUnderflow
+	}
+
+	p, _ := b.read(int(n))
+	return string(p), nil
+}
+
+// WriteBytes writes the bytes as they are
+func (b *Bytes) WriteBytes(p []byte) error {
+	return b.write(p)
+}
+
+// WriteUint8 writes a single byte
+func (b *Bytes) WriteUint8(v uint8) error {
+	return b.write([]byte{v})
+}
+
+// WriteUint16 writes a 16 bit unsigned integer
+func (b *Bytes) WriteUint16(v uint16) error {
+	p := make([]byte, 2)
+	b.order.PutUint16(p, v)
+	return b.write(p)
+}
+
+// WriteUint32 writes a 32 bit unsigned integer
+func (b *Bytes) WriteUint32(v uint32) error {
+	p := make([]byte, 4)
+	b.order.PutUint32(p, v)
+	return b.write(p)
+}
+
+// WriteUint64 writes a 64 bit unsigned integer
+func (b *Bytes) WriteUint64(v uint64) error {
+	p := make([]byte, 8)
+	b.order.PutUint64(p, v)
+	return b.write(p)
+}
+
+// WriteFloat32 writes an IEEE 754 single precision float
+func (b *Bytes) WriteFloat32(v float32) error {
+	return b.WriteUint32(math.Float32bits(v))
+}
+
+// WriteFloat64 writes an IEEE 754 double precision float
+func (b *Bytes) WriteFloat64(v float64) error {
+	return b.WriteUint64(math.Float64bits(v))
+}
+
+// WriteUvarint writes an unsigned integer in the varint encoding used by
+// encoding/binary
+func (b *Bytes) WriteUvarint(v uint64) error {
+	return b.write(binary.AppendUvarint(nil, v))
+}
+
+// WriteVarint writes a signed integer in the zig-zag varint encoding used
+// by encoding/binary
+func (b *Bytes) WriteVarint(v int64) error {
+	return b.write(binary.AppendVarint(nil, v))
+}
+
+// WriteString writes the string prefixed by its length in bytes as a
+// uvarint
+func (b *Bytes) WriteString(s string) error {
+	p := binary.AppendUvarint(nil, uint64(len(s)))
+	return b.write(append(p, s...))
+}
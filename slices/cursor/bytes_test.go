@@ -0,0 +1,212 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Bytes_RoundTrip(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.BigEndian, binary.LittleEndian} {
+		t.Run(order.String(), func(t *testing.T) {
+			b := NewBytes(nil, order)
+
+			writes := []error{
+				b.WriteUint8(0xab),
+				b.WriteUint16(0x1234),
+				b.WriteUint32(0xdeadbeef),
+				b.WriteUint64(math.MaxUint64 - 1),
+				b.WriteFloat32(1.5),
+				b.WriteFloat64(-math.Pi),
+				b.WriteUvarint(300),
+				b.WriteVarint(-12345),
+				b.WriteString("héllo"),
+				b.WriteBytes([]byte{1, 2, 3}),
+			}
+
+			for _, err := range writes {
+				if err != nil {
+					t.Fatalf("expected %v, got %v", nil, err)
+				}
+			}
+
+			if b.Pos() != b.Len() {
+				t.Fatalf("expected %v, got %v", b.Len(), b.Pos())
+			}
+
+			_, _ = b.Seek(0)
+
+			got := []any{
+				must(b.ReadUint8()),
+				must(b.ReadUint16()),
+				must(b.ReadUint32()),
+				must(b.ReadUint64()),
+				must(b.ReadFloat32()),
+				must(b.ReadFloat64()),
+				must(b.ReadUvarint()),
+				must(b.ReadVarint()),
+				must(b.ReadString()),
+				must(b.ReadBytes(3)),
+			}
+
+			want := []any{
+				uint8(0xab),
+				uint16(0x1234),
+				uint32(0xdeadbeef),
+				uint64(math.MaxUint64 - 1),
+				float32(1.5),
+				-math.Pi,
+				uint64(300),
+				int64(-12345),
+				"héllo",
+				[]byte{1, 2, 3},
+			}
+
+			diff := cmp.Diff(got, want)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			if _, err := b.ReadUint8(); err != ErrUnderflow {
+				t.Fatalf("expected %v, got %v", ErrUnderflow, err)
+			}
+		})
+	}
+}
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return v
+}
+
+func Test_Bytes_Order(t *testing.T) {
+	b := NewBytes([]byte{0x01, 0x02, 0x03, 0x04}, binary.LittleEndian)
+
+	v, err := b.ReadUint32()
+	if err != nil || v != 0x04030201 {
+		t.Fatalf("expected %#x, got %#x (%v)", 0x04030201, v, err)
+	}
+
+	b = NewBytes(nil, binary.BigEndian)
+	_ = b.WriteUint16(0x0102)
+
+	diff := cmp.Diff(items(b.Cursor), []byte{0x01, 0x02})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Bytes_Underflow(t *testing.T) {
+	tests := []struct {
+		data []byte
+		read func(b *Bytes) error
+	}{
+		{nil, func(b *Bytes) error { _, err := b.ReadUint8(); return err }},
+		{[]byte{1}, func(b *Bytes) error { _, err := b.ReadUint16(); return err }},
+		{[]byte{1, 2, 3}, func(b *Bytes) error { _, err := b.ReadUint32(); return err }},
+		{[]byte{1, 2, 3, 4, 5, 6, 7}, func(b *Bytes) error { _, err := b.ReadUint64(); return err }},
+		{[]byte{1, 2, 3}, func(b *Bytes) error { _, err := b.ReadFloat32(); return err }},
+		{[]byte{1, 2, 3}, func(b *Bytes) error { _, err := b.ReadFloat64(); return err }},
+		{[]byte{0x80, 0x80}, func(b *Bytes) error { _, err := b.ReadUvarint(); return err }},
+		{[]byte{0x80}, func(b *Bytes) error { _, err := b.ReadVarint(); return err }},
+		{[]byte{5, 'a', 'b'}, func(b *Bytes) error { _, err := b.ReadString(); return err }},
+		{[]byte{0x80}, func(b *Bytes) error { _, err := b.ReadString(); return err }},
+		{[]byte{1, 2}, func(b *Bytes) error { _, err := b.ReadBytes(3); return err }},
+		{[]byte{1, 2}, func(b *Bytes) error { _, err := b.ReadBytes(-1); return err }},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			b := NewBytes(tt.data, binary.BigEndian)
+
+			err := tt.read(b)
+			if err != ErrUnderflow {
+				t.Fatalf("expected %v, got %v", ErrUnderflow, err)
+			}
+
+			if b.Pos() != 0 {
+				t.Fatalf("expected %v, got %v", 0, b.Pos())
+			}
+		})
+	}
+}
+
+func Test_Bytes_ZeroValue(t *testing.T) {
+	reads := []func(b *Bytes) error{
+		func(b *Bytes) error { _, err := b.ReadUint8(); return err },
+		func(b *Bytes) error { _, err := b.ReadUvarint(); return err },
+		func(b *Bytes) error { _, err := b.ReadString(); return err },
+	}
+
+	for i, read := range reads {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			b := &Bytes{Cursor: new(Cursor[byte])}
+
+			if err := read(b); err != ErrUnderflow {
+				t.Fatalf("expected %v, got %v", ErrUnderflow, err)
+			}
+		})
+	}
+
+	b := &Bytes{Cursor: new(Cursor[byte])}
+	if err := b.WriteString("ab"); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	_, _ = b.Seek(0)
+
+	s, err := b.ReadString()
+	if err != nil || s != "ab" {
+		t.Fatalf("expected %q, got %q (%v)", "ab", s, err)
+	}
+}
+
+func Test_Bytes_VarintOverflow(t *testing.T) {
+	data := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}
+	b := NewBytes(data, binary.BigEndian)
+
+	_, err := b.ReadUvarint()
+	if err != ErrOverflow {
+		t.Fatalf("expected %v, got %v", ErrOverflow, err)
+	}
+}
+
+func Test_Bytes_WriteInsert(t *testing.T) {
+	b := NewBytes([]byte{0xaa, 0xbb}, binary.BigEndian)
+	_, _ = b.Seek(1)
+
+	err := b.WriteUint16(0x0102)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(items(b.Cursor), []byte{0xaa, 0x01, 0x02, 0xbb})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	v, err := b.ReadUint8()
+	if err != nil || v != 0xbb {
+		t.Fatalf("expected %#x, got %#x (%v)", 0xbb, v, err)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Cursor_Cap(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(c *Cursor[int]) error
+		want []int
+		err  error
+	}{
+		{"append within", func(c *Cursor[int]) error { return c.Append(4, 5) }, []int{1, 2, 3, 4, 5}, nil},
+		{"append beyond", func(c *Cursor[int]) error { return c.Append(4, 5, 6) }, []int{1, 2, 3}, ErrOverflow},
+		{"prepend within", func(c *Cursor[int]) error { return c.Prepend(4, 5) }, []int{4, 5, 1, 2, 3}, nil},
+		{"prepend beyond", func(c *Cursor[int]) error { return c.Prepend(4, 5, 6) }, []int{1, 2, 3}, ErrOverflow},
+		{"insert within", func(c *Cursor[int]) error { return c.Insert(4, 5) }, []int{4, 5, 1, 2, 3}, nil},
+		{"insert beyond", func(c *Cursor[int]) error { return c.Insert(4, 5, 6) }, []int{1, 2, 3}, ErrOverflow},
+
+		// The final length is checked rather than the position of the insert
+		{"insert at end within", func(c *Cursor[int]) error { return c.InsertAt(2, 4, 5) }, []int{1, 2, 4, 5, 3}, nil},
+		{"insert at start beyond", func(c *Cursor[int]) error { return c.InsertAt(0, 4, 5, 6) }, []int{1, 2, 3}, ErrOverflow},
+
+		{"insert sorted within", func(c *Cursor[int]) error { return c.InsertSorted(0, 9) }, []int{0, 1, 2, 3, 9}, nil},
+		{"insert sorted beyond", func(c *Cursor[int]) error { return c.InsertSorted(0, 4, 9) }, []int{1, 2, 3}, ErrOverflow},
+
+		// Edits which do not grow the cursor are unaffected
+		{"set", func(c *Cursor[int]) error { c.Set(9); return nil }, []int{9, 2, 3}, nil},
+		{"replace", func(c *Cursor[int]) error {
+			out, err := c.ReplaceAt(1, 8, 9)
+			if err == nil {
+				*c = *out
+			}
+			return err
+		}, []int{1, 8, 9}, nil},
+		{"chop", func(c *Cursor[int]) error { return c.Chop(0, 2) }, []int{3}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New([]int{1, 2, 3}, Cap[int](5))
+
+			err := tt.edit(c)
+			if err != tt.err {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			diff := cmp.Diff(items(c), tt.want)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			if c.Available() != 5-len(tt.want) {
+				t.Fatalf("expected %v, got %v", 5-len(tt.want), c.Available())
+			}
+		})
+	}
+}
+
+func Test_Cursor_Cap_Getters(t *testing.T) {
+	tests := []struct {
+		name      string
+		c         *Cursor[int]
+		cap       int
+		available int
+	}{
+		{"unbounded", New([]int{1, 2}), math.MaxInt, math.MaxInt - 2},
+		{"bounded", New([]int{1, 2}, Cap[int](3)), 3, 1},
+		{"full", New([]int{1, 2}, Cap[int](2)), 2, 0},
+		{"over capacity", New([]int{1, 2, 3}, Cap[int](2)), 2, 0},
+		{"negative", New([]int{}, Cap[int](-1)), 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.c.Cap() != tt.cap {
+				t.Fatalf("expected %v, got %v", tt.cap, tt.c.Cap())
+			}
+
+			if tt.c.Available() != tt.available {
+				t.Fatalf("expected %v, got %v", tt.available, tt.c.Available())
+			}
+		})
+	}
+}
+
+func Test_Cursor_Cap_Derived(t *testing.T) {
+	c := New([]int{1, 2, 3}, Cap[int](3))
+
+	_, rem, err := c.Take(1)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if rem.Cap() != 3 || rem.Append(4, 5) != ErrOverflow {
+		t.Fatal("expected the remainder to keep the capacity")
+	}
+
+	if c.Copy().Append(4) != ErrOverflow {
+		t.Fatal("expected the copy to keep the capacity")
+	}
+
+	s := NewSync([]int{1}, Cap[int](2))
+	if err := s.Append(2, 3); err != ErrOverflow {
+		t.Fatalf("expected %v, got %v", ErrOverflow, err)
+	}
+
+	if s.Available() != 1 {
+		t.Fatalf("expected %v, got %v", 1, s.Available())
+	}
+}
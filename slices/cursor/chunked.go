@@ -0,0 +1,147 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"slices"
+	"sort"
+)
+
+// chunkSize is the largest number of elements held by a single chunk
+const chunkSize = 512
+
+// chunkedStorage holds the elements in a list of fixed size chunks so that
+// an edit only rewrites the chunks it touches, rather than the whole buffer.
+type chunkedStorage[T any] struct {
+	chunks [][]T
+
+	// starts holds the index of the first element of each chunk
+	starts []int
+	size   int
+}
+
+// ChunkedStorage returns storage holding the elements in a list of chunks.
+// Edits anywhere in the buffer cost O(chunk size + number of chunks), which
+// suits large buffers edited at scattered positions.
+func ChunkedStorage[T any](data []T) Storage[T] {
+	s := &chunkedStorage[T]{}
+	s.Splice(0, 0, data)
+	return s
+}
+
+// locate returns the chunk holding the element at index i and the offset
+// of the element within it. The end of the buffer is located at the end of
+// the last chunk.
+func (s *chunkedStorage[T]) locate(i int) (int, int) {
+	if len(s.chunks) == 0 {
+		return 0, 0
+	}
+
+	c := sort.SearchInts(s.starts, i+1) - 1
+	return c, i - s.starts[c]
+}
+
+func (s *chunkedStorage[T]) Len() int {
+	return s.size
+}
+
+func (s *chunkedStorage[T]) At(i int) T {
+	c, off := s.locate(i)
+	return s.chunks[c][off]
+}
+
+func (s *chunkedStorage[T]) Set(i int, v T) {
+	c, off := s.locate(i)
+	s.chunks[c][off] = v
+}
+
+func (s *chunkedStorage[T]) Slice(start, end int) []T {
+	out := make([]T, 0, end-start)
+
+	c, off := s.locate(start)
+	for len(out) < end-start {
+		chunk := s.chunks[c][off:]
+		out = append(out, chunk[:min(len(chunk), end-start-len(out))]...)
+		c, off = c+1, 0
+	}
+
+	return out
+}
+
+func (s *chunkedStorage[T]) Splice(start, end int, values []T) {
+	first, off := s.locate(start)
+	last, endOff := s.locate(end)
+
+	// Edit the chunk in place when the edit fits inside it
+	if len(s.chunks) > 0 && first == last {
+		chunk := s.chunks[first]
+		n := len(chunk) + len(values) - (endOff - off)
+		if n > 0 && n <= chunkSize {
+			s.chunks[first] = slices.Replace(chunk, off, endOff, values...)
+			s.shift(first+1, len(values)-(endOff-off))
+			return
+		}
+	}
+
+	// Rebuild the chunks touched by the edit from the elements around it
+	var merged []T
+	if len(s.chunks) > 0 {
+		merged = append(merged, s.chunks[first][:off]...)
+		merged = append(merged, values...)
+		merged = append(merged, s.chunks[last][endOff:]...)
+		last++
+	} else {
+		merged = append(merged, values...)
+	}
+
+	// Fold in the next chunk when the result is small so that repeated
+	// deletes do not leave lots of tiny chunks behind
+	if len(merged) < chunkSize/2 && last < len(s.chunks) {
+		merged = append(merged, s.chunks[last]...)
+		last++
+	}
+
+	var chunks [][]T
+	for len(merged) > 0 {
+		n := min(len(merged), chunkSize)
+		chunk := make([]T, n, chunkSize)
+		copy(chunk, merged)
+		chunks = append(chunks, chunk)
+		merged = merged[n:]
+	}
+
+	s.chunks = slices.Replace(s.chunks, first, last, chunks...)
+	s.size += len(values) - (end - start)
+
+	// Recalculate the starts of the chunks from the edit onwards
+	s.starts = s.starts[:min(first, len(s.starts))]
+	total := 0
+	if first > 0 {
+		total = s.starts[first-1] + len(s.chunks[first-1])
+	}
+
+	for _, chunk := range s.chunks[first:] {
+		s.starts = append(s.starts, total)
+		total += len(chunk)
+	}
+}
+
+// shift moves the starts of the chunks from index i onwards by delta
+func (s *chunkedStorage[T]) shift(i, delta int) {
+	s.size += delta
+	for ; i < len(s.starts); i++ {
+		s.starts[i] += delta
+	}
+}
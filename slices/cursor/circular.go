@@ -0,0 +1,355 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"context"
+	"sync"
+)
+
+// Policy decides what a Ring does when a mutation would take it past its
+// capacity.
+type Policy int
+
+const (
+	// Reject fails the mutation with ErrOverflow, leaving the ring
+	// unchanged
+	Reject Policy = iota
+
+	// Overwrite discards the oldest elements, those at the front of the
+	// ring as it would be after the mutation, until it fits. Values
+	// prepended to a full ring are therefore discarded straight away.
+	Overwrite
+
+	// Block waits until enough elements have been removed for the
+	// mutation to fit. Mutations which could never fit fail with
+	// ErrOverflow rather than waiting forever.
+	Block
+)
+
+// Ring is a cursor with a fixed capacity, held in a circular buffer, for
+// use as a bounded sliding window such as over telemetry samples. The
+// capacity is enforced on every mutation according to its Policy. Ring is
+// safe for concurrent use so that producers and consumers can share it
+// under the Block policy.
+//
+// The oldest elements are at the front of the ring. When elements before
+// the cursor are removed from the front, by Shift or by Overwrite, the
+// cursor moves with the element it was on.
+type Ring[T any] struct {
+	mu       sync.RWMutex
+	c        *Cursor[T]
+	capacity int
+	policy   Policy
+
+	// space is closed and replaced whenever elements are removed, waking
+	// any mutations blocked waiting for room
+	space chan struct{}
+}
+
+// NewRing creates a new empty Ring holding at most capacity elements. A
+// capacity of less than one is treated as one.
+func NewRing[T any](capacity int, policy Policy, opts ...Option[T]) *Ring[T] {
+	opts = append([]Option[T]{WithStorage(RingStorage[T])}, opts...)
+
+	return &Ring[T]{
+		c:        New[T](nil, opts...),
+		capacity: max(capacity, 1),
+		policy:   policy,
+		space:    make(chan struct{}),
+	}
+}
+
+// Cap returns the capacity of the ring
+func (r *Ring[T]) Cap() int {
+	return r.capacity
+}
+
+// Policy returns the policy of the ring
+func (r *Ring[T]) Policy() Policy {
+	return r.policy
+}
+
+// Append adds the values to the back of the ring
+func (r *Ring[T]) Append(values ...T) error {
+	return r.AppendContext(context.Background(), values...)
+}
+
+// AppendContext is like Append but gives up waiting for room under the
+// Block policy when the context is done, returning its error.
+func (r *Ring[T]) AppendContext(ctx context.Context, values ...T) error {
+	return r.grow(ctx, values, func() (int, error) {
+		return r.c.store().Len(), nil
+	}, func(pos int, values []T) error {
+		r.c.splice(pos, pos, values)
+		return nil
+	})
+}
+
+// Prepend adds the values to the front of the ring
+func (r *Ring[T]) Prepend(values ...T) error {
+	return r.grow(context.Background(), values, func() (int, error) {
+		return 0, nil
+	}, func(_ int, values []T) error {
+		return r.c.Prepend(values...)
+	})
+}
+
+// Insert inserts the values at the cursor
+func (r *Ring[T]) Insert(values ...T) error {
+	return r.grow(context.Background(), values, func() (int, error) {
+		return r.at(r.c.pos)
+	}, r.insert)
+}
+
+// InsertAt inserts the values at pos
+func (r *Ring[T]) InsertAt(pos int, values ...T) error {
+	return r.grow(context.Background(), values, func() (int, error) {
+		return r.at(pos)
+	}, r.insert)
+}
+
+// at returns pos if it is a valid index for an insert into the ring
+func (r *Ring[T]) at(pos int) (int, error) {
+	if !r.c.isValidPOS(pos) {
+		return 0, ErrIndexOutOfRange
+	}
+
+	return pos, nil
+}
+
+// insert inserts the values at pos in the cursor
+func (r *Ring[T]) insert(pos int, values []T) error {
+	return r.c.InsertAt(pos, values...)
+}
+
+// grow inserts the values into the ring once the policy allows it, by
+// calling fn with the index returned by at and the values to insert.
+//
+// Under the Overwrite policy the elements which would be discarded from
+// the front after the insert are dropped before it instead, first from
+// the ring and then from the values, so that the ring never holds more
+// than its capacity however many values are inserted.
+func (r *Ring[T]) grow(ctx context.Context, values []T, at func() (int, error), fn func(pos int, values []T) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for r.c.store().Len()+len(values) > r.capacity {
+		switch {
+		case r.policy == Overwrite:
+			pos, err := at()
+			if err != nil {
+				return err
+			}
+
+			// The ring is never over capacity, so no more than the
+			// elements before pos and the values need to be dropped
+			n := r.c.store().Len() + len(values) - r.capacity
+			front := min(n, pos)
+			r.evict(front)
+
+			values = values[n-front:]
+			if len(values) == 0 {
+				return nil
+			}
+
+			return fn(pos-front, values)
+		case r.policy == Block && len(values) <= r.capacity:
+			space := r.space
+
+			r.mu.Unlock()
+			select {
+			case <-space:
+			case <-ctx.Done():
+				r.mu.Lock()
+				return ctx.Err()
+			}
+			r.mu.Lock()
+		default:
+			return ErrOverflow
+		}
+	}
+
+	pos, err := at()
+	if err != nil {
+		return err
+	}
+
+	return fn(pos, values)
+}
+
+// evict removes n elements from the front of the ring, moving the cursor
+// with the element it was on
+func (r *Ring[T]) evict(n int) {
+	if n <= 0 {
+		return
+	}
+
+	r.c.splice(0, n, nil)
+	r.c.move(max(0, r.c.pos-n))
+	r.signal()
+}
+
+// signal wakes any mutations waiting for room
+func (r *Ring[T]) signal() {
+	close(r.space)
+	r.space = make(chan struct{})
+}
+
+// Shift removes and returns the oldest element, at the front of the ring,
+// returning ErrUnderflow if the ring is empty
+func (r *Ring[T]) Shift() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.c.store().Len() == 0 {
+		var out T
+		return out, ErrUnderflow
+	}
+
+	out := r.c.store().At(0)
+	r.evict(1)
+
+	return out, nil
+}
+
+// Delete removes the element at the cursor
+func (r *Ring[T]) Delete() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteAt(r.c.pos)
+}
+
+// DeleteAt removes the element at pos
+func (r *Ring[T]) DeleteAt(pos int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteAt(pos)
+}
+
+// deleteAt removes the element at pos, waking any blocked mutations. The
+// caller must hold the write lock.
+func (r *Ring[T]) deleteAt(pos int) {
+	if r.c.isValidPOS(pos) {
+		r.c.DeleteAt(pos)
+		r.signal()
+	}
+}
+
+// Chop removes the elements from start to end
+func (r *Ring[T]) Chop(start, end int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := r.c.Chop(start, end)
+	if err == nil && end > start {
+		r.signal()
+	}
+
+	return err
+}
+
+// Set replaces the element at the cursor
+func (r *Ring[T]) Set(v T) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.c.Set(v)
+}
+
+// Values returns a copy of every element in the ring, oldest first
+func (r *Ring[T]) Values() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.c.store().Slice(0, r.c.store().Len())
+}
+
+func (r *Ring[T]) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.c.Len()
+}
+
+func (r *Ring[T]) Pos() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.c.Pos()
+}
+
+func (r *Ring[T]) Rem() []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.c.Rem()
+}
+
+func (r *Ring[T]) Slice(start, end int) ([]T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.c.Slice(start, end)
+}
+
+func (r *Ring[T]) Get() (T, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.c.validPOS() {
+		var out T
+		return out, ErrIndexOutOfRange
+	}
+
+	return r.c.store().At(r.c.pos), nil
+}
+
+func (r *Ring[T]) Seek(pos int) (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.c.Seek(pos)
+}
+
+func (r *Ring[T]) Next() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.c.Next()
+}
+
+func (r *Ring[T]) Prev() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.c.Prev()
+}
+
+func (r *Ring[T]) First() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.c.First()
+}
+
+func (r *Ring[T]) Last() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.c.Last()
+}
@@ -0,0 +1,233 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Ring_Policy(t *testing.T) {
+	tests := []struct {
+		policy Policy
+		edit   func(r *Ring[int]) error
+		want   []int
+		err    error
+	}{
+		{Reject, func(r *Ring[int]) error { return r.Append(4) }, []int{1, 2, 3, 4}, nil},
+		{Reject, func(r *Ring[int]) error { return r.Append(4, 5) }, []int{1, 2, 3}, ErrOverflow},
+		{Reject, func(r *Ring[int]) error { return r.Prepend(4, 5) }, []int{1, 2, 3}, ErrOverflow},
+		{Reject, func(r *Ring[int]) error { return r.InsertAt(1, 4, 5) }, []int{1, 2, 3}, ErrOverflow},
+		{Overwrite, func(r *Ring[int]) error { return r.Append(4, 5) }, []int{2, 3, 4, 5}, nil},
+		{Overwrite, func(r *Ring[int]) error { return r.Append(4, 5, 6, 7, 8, 9) }, []int{6, 7, 8, 9}, nil},
+		{Overwrite, func(r *Ring[int]) error { return r.Prepend(4, 5) }, []int{5, 1, 2, 3}, nil},
+		{Overwrite, func(r *Ring[int]) error { return r.InsertAt(1, 4, 5) }, []int{4, 5, 2, 3}, nil},
+		{Overwrite, func(r *Ring[int]) error { return r.InsertAt(2, 4, 5, 6, 7) }, []int{5, 6, 7, 3}, nil},
+		{Overwrite, func(r *Ring[int]) error { return r.InsertAt(3, 4, 5) }, []int{1, 2, 3}, ErrIndexOutOfRange},
+		{Block, func(r *Ring[int]) error { return r.Append(4, 5, 6, 7, 8) }, []int{1, 2, 3}, ErrOverflow},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			r := NewRing[int](4, tt.policy)
+			_ = r.Append(1, 2, 3)
+
+			err := tt.edit(r)
+			if err != tt.err {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			diff := cmp.Diff(r.Values(), tt.want)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func Test_Ring_Window(t *testing.T) {
+	r := NewRing[int](3, Overwrite)
+
+	_ = r.Append(0, 1, 2)
+	_, _ = r.Seek(2)
+
+	for i := 3; i < 100; i++ {
+		if err := r.Append(i); err != nil {
+			t.Fatalf("expected %v, got %v", nil, err)
+		}
+	}
+
+	diff := cmp.Diff(r.Values(), []int{97, 98, 99})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// The cursor follows the element it was on until it is discarded
+	v, err := r.Get()
+	if err != nil || v != 97 {
+		t.Fatalf("expected %v, got %v (%v)", 97, v, err)
+	}
+}
+
+func Test_Ring_Window_Bounded(t *testing.T) {
+	r := NewRing[int](10, Overwrite)
+
+	values := make([]int, 1_000_000)
+	for i := range values {
+		values[i] = i
+	}
+
+	if err := r.Append(values...); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(r.Values(), values[len(values)-10:])
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// Only the values which fit are ever stored
+	data := r.c.store().(*ringStorage[int]).data
+	if len(data) > minRing {
+		t.Fatalf("expected at most %v, got %v", minRing, len(data))
+	}
+}
+
+func Test_Ring_Shift(t *testing.T) {
+	r := NewRing[int](4, Reject)
+	_ = r.Append(1, 2, 3)
+	_, _ = r.Seek(2)
+
+	v, err := r.Shift()
+	if err != nil || v != 1 {
+		t.Fatalf("expected %v, got %v (%v)", 1, v, err)
+	}
+
+	if r.Pos() != 1 {
+		t.Fatalf("expected %v, got %v", 1, r.Pos())
+	}
+
+	_, _ = r.Shift()
+	_, _ = r.Shift()
+
+	_, err = r.Shift()
+	if err != ErrUnderflow {
+		t.Fatalf("expected %v, got %v", ErrUnderflow, err)
+	}
+}
+
+func Test_Ring_Block(t *testing.T) {
+	r := NewRing[int](2, Block)
+
+	const total = 1000
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < total; i++ {
+			if err := r.Append(i); err != nil {
+				t.Errorf("expected %v, got %v", nil, err)
+				return
+			}
+		}
+	}()
+
+	var got []int
+	for len(got) < total {
+		v, err := r.Shift()
+		if err != nil {
+			time.Sleep(time.Microsecond)
+			continue
+		}
+
+		if r.Len() > r.Cap() {
+			t.Fatalf("expected at most %v elements, got %v", r.Cap(), r.Len())
+		}
+
+		got = append(got, v)
+	}
+
+	wg.Wait()
+
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("expected %v, got %v", i, v)
+		}
+	}
+}
+
+func Test_Ring_Block_Context(t *testing.T) {
+	r := NewRing[int](1, Block)
+	_ = r.Append(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	err := r.AppendContext(ctx, 2)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	diff := cmp.Diff(r.Values(), []int{1})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+// Test_Ring_Delete_Shift deletes the element at the cursor while elements
+// are shifted off the front, which moves the cursor. Delete must always
+// remove the element the cursor is on.
+func Test_Ring_Delete_Shift(t *testing.T) {
+	for range 100 {
+		r := NewRing[int](64, Reject)
+		for i := range 40 {
+			_ = r.Append(i)
+		}
+
+		_, _ = r.Seek(30)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				_, _ = r.Shift()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			r.Delete()
+		}()
+		wg.Wait()
+
+		values := r.Values()
+		for _, v := range values {
+			if v == 30 {
+				t.Fatalf("expected 30 to be deleted, got %v", values)
+			}
+		}
+
+		if len(values) != 29 {
+			t.Fatalf("expected %v, got %v", 29, len(values))
+		}
+	}
+}
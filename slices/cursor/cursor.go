@@ -16,17 +16,27 @@ package cursor
 
 import (
 	"errors"
+	"math"
 )
 
 var ErrIndexOutOfRange = errors.New("index out of range")
 var ErrUnderflow = errors.New("underflow")
 var ErrOverflow = errors.New("overflow")
 
+// maxCap is the capacity of a cursor created without the Cap option
+const maxCap = math.MaxInt
+
 type Cursor[T any] struct {
-	buff   []T
-	pos    int
-	cap    int
-	lessFn func(i, j int) bool
+	buff    Storage[T]
+	backend func([]T) Storage[T]
+	pos     int
+	cap     int
+	lessFn  func(i, j int) bool
+	cmpFn   func(a, b T) int
+	marks   []*Mark
+	hist    *history[T]
+
+	observers []func(e Event)
 }
 
 // Slice returns a new slice with the elements from start to end
@@ -37,10 +47,7 @@ func (c *Cursor[T]) Slice(start, end int) ([]T, error) {
 	}
 
 	// Create a new buffer and copy the slice
-	out := make([]T, end-start)
-	copy(out, c.buff[start:end])
-
-	return out, nil
+	return c.store().Slice(start, end), nil
 }
 
 // Chop removes the elements from start to end
@@ -50,7 +57,7 @@ func (c *Cursor[T]) Chop(start, end int) error {
 		return ErrIndexOutOfRange
 	}
 
-	c.buff = append(c.buff[:start], c.buff[end:]...)
+	c.splice(start, end, nil)
 	return nil
 }
 
@@ -59,7 +66,7 @@ func (c *Cursor[T]) First() (T, error) {
 }
 
 func (c *Cursor[T]) Last() (T, error) {
-	return c.Seek(len(c.buff) - 1)
+	return c.Seek(c.store().Len() - 1)
 }
 
 func (c *Cursor[T]) IterFn(f func(T) error) error {
@@ -67,8 +74,8 @@ func (c *Cursor[T]) IterFn(f func(T) error) error {
 		return ErrIndexOutOfRange
 	}
 
-	for c.pos < len(c.buff) {
-		err := f(c.buff[c.pos])
+	for c.pos < c.store().Len() {
+		err := f(c.store().At(c.pos))
 		if err != nil {
 			return err
 		}
@@ -77,38 +84,50 @@ func (c *Cursor[T]) IterFn(f func(T) error) error {
 			break
 		}
 
-		c.pos++
+		c.move(c.pos + 1)
 	}
 
 	return nil
 }
 
 func (c *Cursor[T]) Len() int {
-	return len(c.buff)
+	return c.store().Len()
 }
 
-type hasLess interface {
-	Less(i, j int) bool
+// Pos returns the current position of the cursor
+func (c *Cursor[T]) Pos() int {
+	return c.pos
 }
 
+// Less reports whether the element at index i sorts before the element at
+// index j, using the LessFn option if given and otherwise the comparator
+// of the cursor. Without either every element is treated as equal.
 func (c *Cursor[T]) Less(i, j int) bool {
 	if c.lessFn != nil {
 		return c.lessFn(i, j)
 	}
 
-	var cc T
-	v := any(cc)
-	if h, ok := v.(hasLess); ok {
-		return h.Less(i, j)
+	compare := c.comparator()
+	if compare == nil || !c.isValidPOS(i) || !c.isValidPOS(j) {
+		return false
 	}
 
-	return false
+	return compare(c.store().At(i), c.store().At(j)) < 0
 }
 
+// Swap swaps the elements at index i and j, as a single step of the
+// history which observers see as each of them being replaced
 func (c *Cursor[T]) Swap(i, j int) {
-	if c.isValidPOS(i) && c.isValidPOS(j) {
-		c.buff[i], c.buff[j] = c.buff[j], c.buff[i]
+	if i == j || !c.isValidPOS(i) || !c.isValidPOS(j) {
+		return
 	}
+
+	vi, vj := c.store().At(i), c.store().At(j)
+	_ = c.Group(func() error {
+		c.splice(i, i+1, []T{vj})
+		c.splice(j, j+1, []T{vi})
+		return nil
+	})
 }
 
 func (c *Cursor[T]) Next() (T, error) {
@@ -123,10 +142,20 @@ func (c *Cursor[T]) Get() (T, error) {
 	return c.Seek(c.pos)
 }
 
+// At returns the element at pos without moving the cursor
+func (c *Cursor[T]) At(pos int) (T, error) {
+	if !c.isValidPOS(pos) {
+		var out T
+		return out, ErrIndexOutOfRange
+	}
+
+	return c.store().At(pos), nil
+}
+
 func (c *Cursor[T]) Seek(pos int) (T, error) {
 	if c.isValidPOS(pos) {
-		c.pos = pos
-		return c.buff[c.pos], nil
+		c.move(pos)
+		return c.store().At(c.pos), nil
 	}
 
 	var out T
@@ -134,7 +163,7 @@ func (c *Cursor[T]) Seek(pos int) (T, error) {
 }
 
 func (c *Cursor[T]) isValidPOS(pos int) bool {
-	return pos >= 0 && pos < len(c.buff)
+	return pos >= 0 && pos < c.store().Len()
 }
 
 func (c *Cursor[T]) validPOS() bool {
@@ -143,23 +172,21 @@ func (c *Cursor[T]) validPOS() bool {
 
 func (c *Cursor[T]) Set(v T) {
 	if c.validPOS() {
-		c.buff[c.pos] = v
+		c.splice(c.pos, c.pos+1, []T{v})
 	}
 }
 
 func (c *Cursor[T]) Skip(i int) (*Cursor[T], error) {
 	if c.isValidPOS(c.pos + i) {
-		return New(c.buff[c.pos+i:]), nil
+		return c.derive(c.pos+i, c.store().Len()), nil
 	}
 
-	return New[T](nil), ErrIndexOutOfRange
+	return c.derive(0, 0), ErrIndexOutOfRange
 }
 
 // Rem returns the remaining elements of the cursor as a slice
 func (c *Cursor[T]) Rem() []T {
-	out := make([]T, len(c.buff)-c.pos)
-	copy(out, c.buff[c.pos:])
-	return out
+	return c.store().Slice(min(c.pos, c.store().Len()), c.store().Len())
 }
 
 // Take takes the next X from the cursor if they exist, or returns an error
@@ -169,25 +196,45 @@ func (c *Cursor[T]) Take(i int) ([]T, *Cursor[T], error) {
 		return nil, c, ErrIndexOutOfRange
 	}
 
-	if c.pos+i > len(c.buff) {
+	if c.pos+i > c.store().Len() {
 		return nil, c, ErrUnderflow
 	}
 
-	out := make([]T, i)
-	copy(out, c.buff[c.pos:c.pos+i])
-
-	return out, New(c.buff[c.pos+i:]), nil
+	return c.store().Slice(c.pos, c.pos+i), c.derive(c.pos+i, c.store().Len()), nil
 }
 
 func (c *Cursor[T]) Copy() *Cursor[T] {
-	out := New(c.buff)
+	out := c.derive(0, c.store().Len())
 	out.pos = c.pos
 	return out
 }
 
+// derive returns a new cursor over a copy of the elements from start to
+// end, stored in the same kind of buffer as the cursor
+func (c *Cursor[T]) derive(start, end int) *Cursor[T] {
+	out := New[T](nil)
+	out.backend = c.backend
+	out.cap = c.cap
+	out.cmpFn = c.cmpFn
+	out.buff = c.backend(c.store().Slice(start, end))
+	return out
+}
+
+// clone returns a copy of the cursor along with its options and history,
+// but without any of its marks
+func (c *Cursor[T]) clone() *Cursor[T] {
+	out := c.derive(0, c.store().Len())
+	out.pos = c.pos
+	out.lessFn = c.lessFn
+	out.cmpFn = c.cmpFn
+	out.hist = c.hist.clone()
+	return out
+}
+
 // Replace replaces the next X elements with the given values
 // and returns a new cursor with the updated buffer and the current
 // cursor position
+// The cursor itself is left unchanged
 func (c *Cursor[T]) Replace(values ...T) (*Cursor[T], error) {
 	return c.ReplaceAt(c.pos, values...)
 }
@@ -197,19 +244,12 @@ func (c *Cursor[T]) ReplaceAt(pos int, values ...T) (*Cursor[T], error) {
 		return nil, ErrIndexOutOfRange
 	}
 
-	if pos+len(values) > len(c.buff) {
+	if pos+len(values) > c.store().Len() {
 		return nil, ErrOverflow
 	}
 
-	out := New(append(
-		c.buff[:pos],
-		append(
-			values,
-			c.buff[pos+len(values):]...,
-		)...,
-	))
-
-	out.pos = c.pos
+	out := c.clone()
+	out.splice(pos, pos+len(values), values)
 
 	return out, nil
 }
@@ -220,7 +260,7 @@ func (c *Cursor[T]) Delete() {
 
 func (c *Cursor[T]) DeleteAt(pos int) {
 	if c.isValidPOS(pos) {
-		c.buff = append(c.buff[:pos], c.buff[pos+1:]...)
+		c.splice(pos, pos+1, nil)
 	}
 }
 
@@ -236,33 +276,91 @@ func (c *Cursor[T]) InsertAt(pos int, values ...T) error {
 		return ErrIndexOutOfRange
 	}
 
-	if pos+len(values) > c.cap {
+	if !c.fits(len(values)) {
 		return ErrOverflow
 	}
 
-	c.buff = append(
-		c.buff[:pos],
-		append(
-			values,
-			c.buff[pos:]...,
-		)...,
-	)
-
+	c.splice(pos, pos, values)
 	return nil
 }
 
-func (c *Cursor[T]) Append(values ...T) {
-	c.buff = append(c.buff, values...)
+// Append adds the values to the end of the buffer and returns
+// ErrOverflow, leaving the cursor unchanged, if they do not fit within
+// its capacity
+func (c *Cursor[T]) Append(values ...T) error {
+	if !c.fits(len(values)) {
+		return ErrOverflow
+	}
+
+	c.splice(c.store().Len(), c.store().Len(), values)
+	return nil
 }
 
-func (c *Cursor[T]) Prepend(values ...T) {
-	c.buff = append(
-		append([]T{}, values...),
-		c.buff...,
-	)
+// Prepend adds the values to the start of the buffer and returns
+// ErrOverflow, leaving the cursor unchanged, if they do not fit within
+// its capacity
+func (c *Cursor[T]) Prepend(values ...T) error {
+	if !c.fits(len(values)) {
+		return ErrOverflow
+	}
+
+	c.splice(0, 0, values)
 
 	// shift position
-	c.pos += len(values)
+	c.move(c.pos + len(values))
+	return nil
+}
+
+// Cap returns the maximum number of elements the cursor can hold, which
+// is math.MaxInt unless set by the Cap option
+func (c *Cursor[T]) Cap() int {
+	c.store()
+	return c.cap
+}
+
+// Available returns the number of elements which can be added before the
+// cursor reaches its capacity
+func (c *Cursor[T]) Available() int {
+	n := c.store().Len()
+	return max(c.cap-n, 0)
+}
+
+// fits reports whether n more elements fit within the capacity
+func (c *Cursor[T]) fits(n int) bool {
+	return n <= c.Available()
+}
+
+// store returns the storage of the cursor. A zero value Cursor is set up
+// on first use in the same way as New, as an empty cursor held in a slice
+// without a capacity.
+func (c *Cursor[T]) store() Storage[T] {
+	if c.buff == nil {
+		fresh := New[T](nil)
+		c.backend = fresh.backend
+		c.buff = fresh.buff
+		c.cap = fresh.cap
+		c.cmpFn = fresh.cmpFn
+	}
+
+	return c.buff
+}
+
+// splice replaces the elements from start to end with the given values.
+// Every edit to the buffer goes through splice so that registered marks
+// are kept in step with the contents.
+func (c *Cursor[T]) splice(start, end int, values []T) {
+	c.record(start, end, values)
+
+	if end-start == len(values) {
+		for i, v := range values {
+			c.store().Set(start+i, v)
+		}
+	} else {
+		c.store().Splice(start, end, values)
+	}
+
+	c.shiftMarks(start, end, len(values))
+	c.notifySplice(start, end, values)
 }
 
 type Option[T any] func(*Cursor[T])
@@ -273,9 +371,14 @@ func LessFn[T any](fn func(i, j int) bool) Option[T] {
 	}
 }
 
+// Cap limits the number of elements the cursor can hold. Every operation
+// which adds elements fails with ErrOverflow if the resulting length would
+// exceed the capacity, while a cursor created over more elements than its
+// capacity keeps them but cannot grow. Cursors derived from the cursor,
+// such as by Take or Copy, share the same capacity.
 func Cap[T any](capacity int) Option[T] {
 	return func(c *Cursor[T]) {
-		c.cap = capacity
+		c.cap = max(capacity, 0)
 	}
 }
 
@@ -288,16 +391,20 @@ func New[T any](buff []T, opts ...Option[T]) *Cursor[T] {
 	copy(copyBuff, buff)
 
 	out := &Cursor[T]{
-		buff: copyBuff,
-		pos:  0,
-
-		// max value for int
-		cap: int(^uint(0) >> 1),
+		backend: SliceStorage[T],
+		pos:     0,
+		cap:     maxCap,
 	}
 
 	for _, opt := range opts {
 		opt(out)
 	}
 
+	if out.cmpFn == nil && out.lessFn == nil {
+		out.cmpFn = orderedCompare[T]()
+	}
+
+	out.buff = out.backend(copyBuff)
+
 	return out
 }
@@ -21,6 +21,11 @@ import (
 	"github.com/google/go-cmp/cmp"
 )
 
+// items returns a copy of every element in the buffer of the cursor
+func items[T any](c *Cursor[T]) []T {
+	return c.buff.Slice(0, c.buff.Len())
+}
+
 func Test_New(t *testing.T) {
 	c := New([]int{1, 2, 3, 4, 5})
 	if c == nil {
@@ -35,11 +40,11 @@ func Test_New(t *testing.T) {
 		t.Fatal("expected a cursor with position 0")
 	}
 
-	if c.buff[0] != 1 {
+	if items(c)[0] != 1 {
 		t.Fatal("expected a cursor with first element 1")
 	}
 
-	if c.buff[4] != 5 {
+	if items(c)[4] != 5 {
 		t.Fatal("expected a cursor with last element 5")
 	}
 }
@@ -189,7 +194,7 @@ func Test_Cursor_Set(t *testing.T) {
 			c.pos = tt.pos
 			c.Set(tt.val)
 
-			for i, v := range c.buff {
+			for i, v := range items(c) {
 				if tt.want[i] != v {
 					t.Fatalf("expected %v, got %v", tt.want[i], v)
 				}
@@ -209,66 +214,42 @@ func Test_Cursor_Take(t *testing.T) {
 	}{
 		{
 			[]int{1, 2, 3, 4, 5}, 0, 3, []int{1, 2, 3},
-			&Cursor[int]{
-				buff: []int{4, 5},
-				pos:  0,
-			},
+			New([]int{4, 5}),
 			nil,
 		},
 		{
 			[]int{1, 2, 3, 4, 5}, 0, 5, []int{1, 2, 3, 4, 5},
-			&Cursor[int]{
-				buff: []int{},
-				pos:  0,
-			},
+			New([]int{}),
 			nil,
 		},
 		{
 			[]int{1, 2, 3, 4, 5}, 0, 6, []int{},
-			&Cursor[int]{
-				buff: []int{1, 2, 3, 4, 5},
-				pos:  0,
-			},
+			New([]int{1, 2, 3, 4, 5}),
 			ErrUnderflow,
 		},
 		{
 			[]int{1, 2, 3, 4, 5}, 0, 0, []int{},
-			&Cursor[int]{
-				buff: []int{1, 2, 3, 4, 5},
-				pos:  0,
-			},
+			New([]int{1, 2, 3, 4, 5}),
 			nil,
 		},
 		{
 			[]int{1, 2, 3, 4, 5}, 0, -1, []int{},
-			&Cursor[int]{
-				buff: []int{1, 2, 3, 4, 5},
-				pos:  0,
-			},
+			New([]int{1, 2, 3, 4, 5}),
 			ErrIndexOutOfRange,
 		},
 		{
 			[]int{1, 2, 3, 4, 5}, 0, 1, []int{1},
-			&Cursor[int]{
-				buff: []int{2, 3, 4, 5},
-				pos:  0,
-			},
+			New([]int{2, 3, 4, 5}),
 			nil,
 		},
 		{
 			[]int{1, 2, 3, 4, 5}, 0, 2, []int{1, 2},
-			&Cursor[int]{
-				buff: []int{3, 4, 5},
-				pos:  0,
-			},
+			New([]int{3, 4, 5}),
 			nil,
 		},
 		{
 			[]int{1, 2, 3, 4, 5}, 0, 4, []int{1, 2, 3, 4},
-			&Cursor[int]{
-				buff: []int{5},
-				pos:  0,
-			},
+			New([]int{5}),
 			nil,
 		},
 	}
@@ -288,9 +269,9 @@ func Test_Cursor_Take(t *testing.T) {
 				}
 			}
 
-			for i, v := range left.buff {
-				if tt.rem.buff[i] != v {
-					t.Fatalf("expected %v, got %v", tt.rem.buff[i], v)
+			for i, v := range items(left) {
+				if items(tt.rem)[i] != v {
+					t.Fatalf("expected %v, got %v", items(tt.rem)[i], v)
 				}
 			}
 		})
@@ -370,7 +351,7 @@ func Test_Cursor_Replace(t *testing.T) {
 				return
 			}
 
-			diff := cmp.Diff(newC.buff, tt.want)
+			diff := cmp.Diff(items(newC), tt.want)
 			if diff != "" {
 				t.Fatalf(diff)
 			}
@@ -448,7 +429,7 @@ func Test_Cursor_ReplaceAt(t *testing.T) {
 				return
 			}
 
-			diff := cmp.Diff(newC.buff, tt.want)
+			diff := cmp.Diff(items(newC), tt.want)
 			if diff != "" {
 				t.Fatalf(diff)
 			}
@@ -505,7 +486,7 @@ func Test_Cursor_Delete(t *testing.T) {
 			c := New(tt.data)
 			c.DeleteAt(tt.pos)
 
-			diff := cmp.Diff(c.buff, tt.want)
+			diff := cmp.Diff(items(c), tt.want)
 			if diff != "" {
 				t.Fatalf(diff)
 			}
@@ -577,7 +558,7 @@ func Test_Cursor_InsertAt(t *testing.T) {
 				return
 			}
 
-			diff := cmp.Diff(c.buff, tt.want)
+			diff := cmp.Diff(items(c), tt.want)
 			if diff != "" {
 				t.Fatalf(diff)
 			}
@@ -610,7 +591,7 @@ func Test_Cursor_Append(t *testing.T) {
 			c := New(tt.data)
 			c.Append(tt.values...)
 
-			diff := cmp.Diff(c.buff, tt.want)
+			diff := cmp.Diff(items(c), tt.want)
 			if diff != "" {
 				t.Fatalf(diff)
 			}
@@ -643,7 +624,7 @@ func Test_Cursor_Prepend(t *testing.T) {
 			c := New(tt.data)
 			c.Prepend(tt.values...)
 
-			diff := cmp.Diff(c.buff, tt.want)
+			diff := cmp.Diff(items(c), tt.want)
 			if diff != "" {
 				t.Fatalf(diff)
 			}
@@ -701,10 +682,61 @@ func Test_Cursor_Skip(t *testing.T) {
 				t.Fatalf("expected %v, got %v", tt.err, err)
 			}
 
-			diff := cmp.Diff(got.buff, tt.want)
+			diff := cmp.Diff(items(got), tt.want)
 			if diff != "" {
 				t.Fatalf(diff)
 			}
 		})
 	}
 }
+
+func Test_Cursor_At(t *testing.T) {
+	c := New([]int{1, 2, 3})
+
+	v, err := c.At(2)
+	if err != nil || v != 3 {
+		t.Fatalf("expected %v, got %v (%v)", 3, v, err)
+	}
+
+	if c.Pos() != 0 {
+		t.Fatalf("expected %v, got %v", 0, c.Pos())
+	}
+
+	if _, err = c.At(3); err != ErrIndexOutOfRange {
+		t.Fatalf("expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+}
+
+func Test_Cursor_ZeroValue(t *testing.T) {
+	var c Cursor[int]
+
+	if c.Len() != 0 {
+		t.Fatalf("expected %v, got %v", 0, c.Len())
+	}
+
+	if _, err := c.Get(); err != ErrIndexOutOfRange {
+		t.Fatalf("expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+
+	if c.Available() <= 0 {
+		t.Fatalf("expected room to grow, got %v", c.Available())
+	}
+
+	if err := c.Append(3, 1, 2); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if err := c.Sort(); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(items(&c), []int{1, 2, 3})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	var other Cursor[int]
+	if len(other.Rem()) != 0 || other.Copy().Len() != 0 {
+		t.Fatal("expected an empty cursor")
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cursortest provides the conformance suite which every
+// cursor.Storage implementation must pass.
+package cursortest
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"go.devnw.com/ds/slices/cursor"
+)
+
+// TestStorage checks that the storage returned by fn behaves exactly like a
+// plain slice, both when used directly and when used as the storage of a
+// cursor. The storage returned by fn must take ownership of the slice it is
+// given.
+func TestStorage(t *testing.T, fn func(data []int) cursor.Storage[int]) {
+	t.Helper()
+
+	t.Run("Empty", func(t *testing.T) { testEmpty(t, fn) })
+	t.Run("Access", func(t *testing.T) { testAccess(t, fn) })
+	t.Run("Splice", func(t *testing.T) { testSplice(t, fn) })
+	t.Run("Random", func(t *testing.T) { testRandom(t, fn) })
+	t.Run("Cursor", func(t *testing.T) { testCursor(t, fn) })
+	t.Run("Derived", func(t *testing.T) { testDerived(t, fn) })
+}
+
+func seq(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i
+	}
+
+	return out
+}
+
+// check compares the storage against the expected elements
+func check(t *testing.T, s cursor.Storage[int], want []int) {
+	t.Helper()
+
+	if s.Len() != len(want) {
+		t.Fatalf("expected length %v, got %v", len(want), s.Len())
+	}
+
+	for i, v := range want {
+		if got := s.At(i); got != v {
+			t.Fatalf("expected %v at %v, got %v", v, i, got)
+		}
+	}
+
+	got := s.Slice(0, s.Len())
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func testEmpty(t *testing.T, fn func([]int) cursor.Storage[int]) {
+	for _, data := range [][]int{nil, {}} {
+		s := fn(data)
+		check(t, s, nil)
+
+		if got := s.Slice(0, 0); got == nil || len(got) != 0 {
+			t.Fatalf("expected an empty slice, got %#v", got)
+		}
+
+		s.Splice(0, 0, []int{1, 2})
+		check(t, s, []int{1, 2})
+
+		s.Splice(0, 2, nil)
+		check(t, s, nil)
+	}
+}
+
+func testAccess(t *testing.T, fn func([]int) cursor.Storage[int]) {
+	for _, n := range []int{1, 7, 100, 2000} {
+		want := seq(n)
+		s := fn(seq(n))
+		check(t, s, want)
+
+		for i := 0; i < n; i += 3 {
+			s.Set(i, -i)
+			want[i] = -i
+		}
+		check(t, s, want)
+
+		for _, r := range [][2]int{{0, 0}, {0, 1}, {n / 3, n / 2}, {n - 1, n}, {n, n}} {
+			got := s.Slice(r[0], r[1])
+			if !slices.Equal(got, want[r[0]:r[1]]) {
+				t.Fatalf("expected %v, got %v", want[r[0]:r[1]], got)
+			}
+
+			// The slice must be a copy of the elements
+			if len(got) > 0 {
+				got[0] = 1 << 30
+				if s.At(r[0]) == 1<<30 {
+					t.Fatal("expected Slice to return a copy")
+				}
+			}
+		}
+	}
+}
+
+func testSplice(t *testing.T, fn func([]int) cursor.Storage[int]) {
+	tests := []struct {
+		name       string
+		start, end int
+		values     []int
+	}{
+		{"insert at start", 0, 0, []int{-1, -2}},
+		{"insert in middle", 5, 5, []int{-1, -2, -3}},
+		{"insert at end", 10, 10, []int{-1}},
+		{"delete at start", 0, 2, nil},
+		{"delete in middle", 3, 7, nil},
+		{"delete at end", 8, 10, nil},
+		{"delete all", 0, 10, nil},
+		{"replace same length", 2, 4, []int{-1, -2}},
+		{"replace shorter", 2, 6, []int{-1}},
+		{"replace longer", 2, 3, []int{-1, -2, -3, -4}},
+		{"replace all", 0, 10, []int{-1}},
+		{"no-op", 4, 4, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := fn(seq(10))
+			s.Splice(tt.start, tt.end, slices.Clone(tt.values))
+			check(t, s, slices.Replace(seq(10), tt.start, tt.end, tt.values...))
+		})
+	}
+}
+
+// testRandom applies random edits to the storage and to a plain slice and
+// checks that they stay in step
+func testRandom(t *testing.T, fn func([]int) cursor.Storage[int]) {
+	r := rand.New(rand.NewSource(1))
+
+	want := seq(50)
+	s := fn(seq(50))
+
+	for i := 0; i < 2000; i++ {
+		start := r.Intn(len(want) + 1)
+		end := start + r.Intn(len(want)-start+1)
+
+		// Favour short edits, with the odd large one
+		if r.Intn(10) > 0 {
+			end = min(end, start+r.Intn(5))
+		}
+
+		n := r.Intn(6)
+		if r.Intn(20) == 0 {
+			n = r.Intn(1500)
+		}
+
+		values := make([]int, n)
+		for j := range values {
+			values[j] = r.Int()
+		}
+
+		if r.Intn(4) == 0 && start < len(want) {
+			v := r.Int()
+			s.Set(start, v)
+			want[start] = v
+		} else {
+			s.Splice(start, end, slices.Clone(values))
+			want = slices.Replace(want, start, end, values...)
+		}
+
+		if s.Len() != len(want) {
+			t.Fatalf("edit %v: expected length %v, got %v", i, len(want), s.Len())
+		}
+
+		if i%50 == 0 {
+			check(t, s, want)
+		}
+	}
+
+	check(t, s, want)
+}
+
+// testCursor runs the cursor operations over the storage
+func testCursor(t *testing.T, fn func([]int) cursor.Storage[int]) {
+	c := cursor.New(seq(5), cursor.WithStorage(fn))
+
+	v, err := c.Seek(2)
+	if err != nil || v != 2 {
+		t.Fatalf("expected %v, got %v (%v)", 2, v, err)
+	}
+
+	v, err = c.Next()
+	if err != nil || v != 3 {
+		t.Fatalf("expected %v, got %v (%v)", 3, v, err)
+	}
+
+	v, err = c.Prev()
+	if err != nil || v != 2 {
+		t.Fatalf("expected %v, got %v (%v)", 2, v, err)
+	}
+
+	if _, err = c.Seek(5); err != cursor.ErrIndexOutOfRange {
+		t.Fatalf("expected %v, got %v", cursor.ErrIndexOutOfRange, err)
+	}
+
+	c.Set(20)
+	if err = c.Insert(10, 11); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	c.Append(5, 6)
+	c.Prepend(-1)
+	c.DeleteAt(1)
+
+	if err = c.InsertAt(1, 0); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if err = c.Chop(6, 7); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	want := []int{-1, 0, 1, 10, 11, 20, 4, 5, 6}
+	got, err := c.Slice(0, c.Len()-1)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if !slices.Equal(got, want[:len(want)-1]) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	v, err = c.Last()
+	if err != nil || v != 6 {
+		t.Fatalf("expected %v, got %v (%v)", 6, v, err)
+	}
+
+	var all []int
+	for _, v := range c.All() {
+		all = append(all, v)
+	}
+
+	if !slices.Equal(all, want) {
+		t.Fatalf("expected %v, got %v", want, all)
+	}
+
+	_, _ = c.Seek(3)
+	c.Delete()
+
+	if rem := c.Rem(); !slices.Equal(rem, []int{11, 20, 4, 5, 6}) {
+		t.Fatalf("expected %v, got %v", []int{11, 20, 4, 5, 6}, rem)
+	}
+}
+
+// testDerived checks that cursors derived from the cursor are independent
+// of it
+func testDerived(t *testing.T, fn func([]int) cursor.Storage[int]) {
+	c := cursor.New(seq(6), cursor.WithStorage(fn))
+	_, _ = c.Seek(1)
+
+	taken, rem, err := c.Take(2)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if !slices.Equal(taken, []int{1, 2}) {
+		t.Fatalf("expected %v, got %v", []int{1, 2}, taken)
+	}
+
+	rem.Append(100)
+	if c.Len() != 6 || rem.Len() != 4 {
+		t.Fatalf("expected lengths %v and %v, got %v and %v", 6, 4, c.Len(), rem.Len())
+	}
+
+	cp := c.Copy()
+	cp.Set(50)
+	if v, _ := c.Get(); v != 1 {
+		t.Fatalf("expected %v, got %v", 1, v)
+	}
+
+	replaced, err := c.Replace(7, 8)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	got, _ := replaced.Slice(0, 3)
+	if !slices.Equal(got, []int{0, 7, 8}) {
+		t.Fatalf("expected %v, got %v", []int{0, 7, 8}, got)
+	}
+
+	if v, _ := c.Get(); v != 1 {
+		t.Fatalf("expected %v, got %v", 1, v)
+	}
+}
@@ -0,0 +1,444 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrPatchMismatch = errors.New("patch does not match the cursor")
+
+// Op is the kind of change made by a Hunk
+type Op int
+
+const (
+	// Equal keeps elements which are in both sequences
+	Equal Op = iota
+
+	// Insert adds elements which are only in the second sequence
+	Insert
+
+	// Delete removes elements which are only in the first sequence
+	Delete
+)
+
+func (o Op) String() string {
+	switch o {
+	case Equal:
+		return "equal"
+	case Insert:
+		return "insert"
+	case Delete:
+		return "delete"
+	default:
+		return fmt.Sprintf("Op(%d)", int(o))
+	}
+}
+
+// Hunk is a run of elements which are kept, inserted or deleted by a
+// Patch. A and B are the indexes of the run in the first and second
+// sequence, which for an insert or delete is where the run would start in
+// the sequence it is missing from.
+type Hunk[T any] struct {
+	Op     Op
+	A      int
+	B      int
+	Values []T
+}
+
+// Patch is an edit script which turns one sequence into another, made up
+// of hunks in the order they apply.
+type Patch[T any] []Hunk[T]
+
+// Diff returns the shortest edit script turning the elements of a into the
+// elements of b, using Myers' algorithm. Both cursors are compared in full
+// regardless of their positions.
+func Diff[T comparable](a, b *Cursor[T]) Patch[T] {
+	return DiffFunc(a, b, func(x, y T) bool {
+		return x == y
+	})
+}
+
+// DiffFunc is like Diff but compares the elements with eq
+func DiffFunc[T any](a, b *Cursor[T], eq func(x, y T) bool) Patch[T] {
+	sa, sb := a.store(), b.store()
+	return myers(sa.Slice(0, sa.Len()), sb.Slice(0, sb.Len()), eq)
+}
+
+// step is a single element kept, inserted or deleted by a diff, at index x
+// of the first sequence and index y of the second
+type step struct {
+	op   Op
+	x, y int
+}
+
+// differ finds the shortest edit script between two sequences using the
+// linear space variant of Myers' algorithm, which splits the problem at
+// the middle of an optimal path and solves each half in turn.
+type differ[T any] struct {
+	a, b  []T
+	eq    func(x, y T) bool
+	steps []step
+}
+
+// myers returns the shortest edit script turning a into b
+func myers[T any](a, b []T, eq func(x, y T) bool) Patch[T] {
+	d := &differ[T]{a: a, b: b, eq: eq}
+	d.diff(0, len(a), 0, len(b))
+
+	// Join consecutive steps of the same kind into hunks, putting the
+	// deletes of each change before its inserts
+	var patch Patch[T]
+	add := func(op Op, x, y int, value T) {
+		last := len(patch) - 1
+		if last >= 0 && patch[last].Op == op {
+			patch[last].Values = append(patch[last].Values, value)
+			return
+		}
+
+		patch = append(patch, Hunk[T]{Op: op, A: x, B: y, Values: []T{value}})
+	}
+
+	var inserts []step
+	for _, s := range d.steps {
+		switch s.op {
+		case Insert:
+			inserts = append(inserts, s)
+			continue
+		case Equal:
+			for _, ins := range inserts {
+				add(Insert, s.x, ins.y, b[ins.y])
+			}
+
+			inserts = inserts[:0]
+		case Delete:
+			// The deletes move ahead of any inserts before them in the
+			// change, so they start where the first of those inserts did
+			if len(inserts) > 0 {
+				s.y = inserts[0].y
+			}
+		}
+
+		add(s.op, s.x, s.y, a[s.x])
+	}
+
+	for _, ins := range inserts {
+		add(Insert, len(a), ins.y, b[ins.y])
+	}
+
+	return patch
+}
+
+// diff records the steps turning a[a0:a1] into b[b0:b1]
+func (d *differ[T]) diff(a0, a1, b0, b1 int) {
+	for a0 < a1 && b0 < b1 && d.eq(d.a[a0], d.b[b0]) {
+		d.steps = append(d.steps, step{Equal, a0, b0})
+		a0, b0 = a0+1, b0+1
+	}
+
+	suffix := 0
+	for a0 < a1-suffix && b0 < b1-suffix && d.eq(d.a[a1-suffix-1], d.b[b1-suffix-1]) {
+		suffix++
+	}
+
+	a1, b1 = a1-suffix, b1-suffix
+
+	switch {
+	case a0 == a1:
+		for y := b0; y < b1; y++ {
+			d.steps = append(d.steps, step{Insert, a0, y})
+		}
+	case b0 == b1:
+		for x := a0; x < a1; x++ {
+			d.steps = append(d.steps, step{Delete, x, b0})
+		}
+	default:
+		x, y, ok := d.middle(a0, a1, b0, b1)
+		if ok {
+			d.diff(a0, x, b0, y)
+			d.diff(x, a1, y, b1)
+			break
+		}
+
+		// The sequences have nothing in common
+		for x := a0; x < a1; x++ {
+			d.steps = append(d.steps, step{Delete, x, b0})
+		}
+
+		for y := b0; y < b1; y++ {
+			d.steps = append(d.steps, step{Insert, a1, y})
+		}
+	}
+
+	for i := range suffix {
+		d.steps = append(d.steps, step{Equal, a1 + i, b1 + i})
+	}
+}
+
+// middle returns a point on an optimal path from the start to the end of
+// a[a0:a1] and b[b0:b1], found by searching forwards from the start and
+// backwards from the end at the same time until the searches overlap. The
+// sequences must both be non-empty and differ in their first and last
+// elements, so the point always splits the problem into smaller ones. It
+// reports false if the searches never meet, which only happens when the
+// sequences have no elements in common.
+func (d *differ[T]) middle(a0, a1, b0, b1 int) (int, int, bool) {
+	n, m := a1-a0, b1-b0
+	limit := (n + m + 1) / 2
+	offset := limit + 1
+
+	// forward and backward hold the furthest distance travelled from the
+	// start and from the end along each diagonal, indexed by k+offset
+	forward := make([]int, 2*offset+1)
+	backward := make([]int, 2*offset+1)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+
+	forward[offset+1], backward[offset+1] = 0, 0
+
+	// The searches meet on a forward step when the total number of edits
+	// is odd and on a backward step when it is even
+	delta := n - m
+	odd := delta%2 != 0
+
+	// Diagonals whose paths have run off the edge of the grid are trimmed
+	// from the ends of the range searched in later steps
+	var fstart, fend, bstart, bend int
+
+	for e := 0; e < limit; e++ {
+		for k := -e + fstart; k <= e-fend; k += 2 {
+			var x int
+			if k == -e || (k != e && forward[offset+k-1] < forward[offset+k+1]) {
+				x = forward[offset+k+1]
+			} else {
+				x = forward[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && d.eq(d.a[a0+x], d.b[b0+y]) {
+				x, y = x+1, y+1
+			}
+
+			forward[offset+k] = x
+
+			switch {
+			case x > n:
+				fend += 2
+			case y > m:
+				fstart += 2
+			case odd:
+				back := offset + delta - k
+				if back >= 0 && back < len(backward) && backward[back] != -1 &&
+					x >= n-backward[back] {
+					return a0 + x, b0 + y, true
+				}
+			}
+		}
+
+		for k := -e + bstart; k <= e-bend; k += 2 {
+			var x int
+			if k == -e || (k != e && backward[offset+k-1] < backward[offset+k+1]) {
+				x = backward[offset+k+1]
+			} else {
+				x = backward[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < n && y < m && d.eq(d.a[a1-x-1], d.b[b1-y-1]) {
+				x, y = x+1, y+1
+			}
+
+			backward[offset+k] = x
+
+			switch {
+			case x > n:
+				bend += 2
+			case y > m:
+				bstart += 2
+			case !odd:
+				front := offset + delta - k
+				if front >= 0 && front < len(forward) && forward[front] != -1 &&
+					forward[front] >= n-x {
+					fx := forward[front]
+					return a0 + fx, b0 + fx - (front - offset), true
+				}
+			}
+		}
+	}
+
+	return 0, 0, false
+}
+
+// Apply replays the patch onto the cursor, turning the sequence the patch
+// was made from into the sequence it was made to. The edits are grouped
+// into a single step of the history. ErrPatchMismatch is returned if the
+// cursor is not the length the patch expects and ErrOverflow if the
+// result would not fit within its capacity, in both cases leaving the
+// cursor unchanged. The elements are not compared, so a patch applied to
+// a different sequence of the same length is not detected.
+func (c *Cursor[T]) Apply(p Patch[T]) error {
+	from, to := 0, 0
+	for _, h := range p {
+		switch h.Op {
+		case Equal:
+			from += len(h.Values)
+			to += len(h.Values)
+		case Insert:
+			to += len(h.Values)
+		case Delete:
+			from += len(h.Values)
+		}
+	}
+
+	if from != c.store().Len() {
+		return ErrPatchMismatch
+	}
+
+	if to > c.store().Len() && !c.fits(to-from) {
+		return ErrOverflow
+	}
+
+	// The final length was checked above, so the hunks are spliced in
+	// directly rather than checking each one against the capacity
+	return c.Group(func() error {
+		pos := 0
+		for _, h := range p {
+			n := len(h.Values)
+
+			switch h.Op {
+			case Equal:
+				pos += n
+			case Insert:
+				c.splice(pos, pos, h.Values)
+				pos += n
+			case Delete:
+				c.splice(pos, pos+n, nil)
+			}
+		}
+
+		// Keep the cursor within the buffer if it shrank
+		if c.pos >= c.store().Len() {
+			c.move(max(c.store().Len()-1, 0))
+		}
+
+		return nil
+	})
+}
+
+// Unified renders the patch between two line buffers in the unified diff
+// format, with the given number of unchanged lines of context around each
+// change. The lines must not include their line endings. An empty string
+// is returned if the patch makes no changes.
+func Unified(p Patch[string], from, to string, context int) string {
+	type line struct {
+		op   Op
+		text string
+
+		// a and b are the indexes of the line in each buffer, or where it
+		// would be for a line missing from one of them
+		a, b int
+	}
+
+	var lines []line
+	var changes []int
+	for _, h := range p {
+		for i, text := range h.Values {
+			l := line{op: h.Op, text: text, a: h.A, b: h.B}
+			switch h.Op {
+			case Equal:
+				l.a, l.b = h.A+i, h.B+i
+			case Insert:
+				l.b = h.B + i
+			case Delete:
+				l.a = h.A + i
+			}
+
+			if h.Op != Equal {
+				changes = append(changes, len(lines))
+			}
+
+			lines = append(lines, l)
+		}
+	}
+
+	if len(changes) == 0 {
+		return ""
+	}
+
+	context = max(context, 0)
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", from, to)
+
+	for i := 0; i < len(changes); {
+		// Extend the hunk while the context of the next change overlaps it
+		start := max(changes[i]-context, 0)
+		end := min(changes[i]+context+1, len(lines))
+		for i++; i < len(changes) && changes[i]-context <= end; i++ {
+			end = min(changes[i]+context+1, len(lines))
+		}
+
+		hunk := lines[start:end]
+
+		aStart, bStart := hunk[0].a, hunk[0].b
+		aLen, bLen := 0, 0
+		for _, l := range hunk {
+			if l.op != Insert {
+				aLen++
+			}
+
+			if l.op != Delete {
+				bLen++
+			}
+		}
+
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n",
+			unifiedRange(aStart, aLen),
+			unifiedRange(bStart, bLen),
+		)
+
+		for _, l := range hunk {
+			switch l.op {
+			case Equal:
+				out.WriteByte(' ')
+			case Insert:
+				out.WriteByte('+')
+			case Delete:
+				out.WriteByte('-')
+			}
+
+			out.WriteString(l.text)
+			out.WriteByte('\n')
+		}
+	}
+
+	return out.String()
+}
+
+// unifiedRange formats the range of a hunk header, where lines are counted
+// from 1 and an empty range starts at the line before it
+func unifiedRange(start, n int) string {
+	switch n {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, n)
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// lcs returns the length of the longest common subsequence of a and b
+func lcs(a, b []int) int {
+	prev := make([]int, len(b)+1)
+	for i := range a {
+		next := make([]int, len(b)+1)
+		for j := range b {
+			switch {
+			case a[i] == b[j]:
+				next[j+1] = prev[j] + 1
+			default:
+				next[j+1] = max(prev[j+1], next[j])
+			}
+		}
+
+		prev = next
+	}
+
+	return prev[len(b)]
+}
+
+func Test_Diff(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want Patch[rune]
+	}{
+		{"abc", "abc", Patch[rune]{
+			{Op: Equal, A: 0, B: 0, Values: []rune("abc")},
+		}},
+		{"", "ab", Patch[rune]{
+			{Op: Insert, A: 0, B: 0, Values: []rune("ab")},
+		}},
+		{"ab", "", Patch[rune]{
+			{Op: Delete, A: 0, B: 0, Values: []rune("ab")},
+		}},
+		{"", "", nil},
+		{"abcd", "axcyd", Patch[rune]{
+			{Op: Equal, A: 0, B: 0, Values: []rune("a")},
+			{Op: Delete, A: 1, B: 1, Values: []rune("b")},
+			{Op: Insert, A: 2, B: 1, Values: []rune("x")},
+			{Op: Equal, A: 2, B: 2, Values: []rune("c")},
+			{Op: Insert, A: 3, B: 3, Values: []rune("y")},
+			{Op: Equal, A: 3, B: 4, Values: []rune("d")},
+		}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			got := Diff(New([]rune(tt.a)), New([]rune(tt.b)))
+
+			diff := cmp.Diff(got, tt.want)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func Test_Diff_ZeroValue(t *testing.T) {
+	var a, b Cursor[int]
+
+	if got := Diff(&a, &b); got != nil {
+		t.Fatalf("expected %v, got %v", nil, got)
+	}
+
+	patch := Diff(&a, New([]int{1, 2}))
+	if err := a.Apply(patch); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(items(&a), []int{1, 2})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Diff_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	alphabet := 4
+	random := func() []int {
+		out := make([]int, rng.Intn(30))
+		for i := range out {
+			out[i] = rng.Intn(alphabet)
+		}
+
+		return out
+	}
+
+	for i := range 400 {
+		// Use a larger alphabet for half of the tests so that some inputs
+		// have little in common
+		alphabet = 4 + i%2*20
+		a, b := random(), random()
+
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			c := New(a, WithStorage(GapStorage[int]))
+			patch := Diff(c, New(b))
+
+			// The script must be the shortest possible
+			edits := 0
+			for _, h := range patch {
+				if h.Op != Equal {
+					edits += len(h.Values)
+				}
+			}
+
+			if want := len(a) + len(b) - 2*lcs(a, b); edits != want {
+				t.Fatalf("expected %v edits, got %v", want, edits)
+			}
+
+			err := c.Apply(patch)
+			if err != nil {
+				t.Fatalf("expected %v, got %v", nil, err)
+			}
+
+			diff := cmp.Diff(items(c), b)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+// Test_Diff_Large checks that the memory used grows with the length of the
+// inputs rather than with the length times the number of edits
+func Test_Diff_Large(t *testing.T) {
+	const n = 5000
+
+	a, b := make([]int, n), make([]int, n)
+	for i := range n {
+		a[i], b[i] = i, n+i
+	}
+
+	// Keep a few lines in common so the search has to find them
+	for i := 0; i < n; i += 1000 {
+		b[i] = a[i]
+	}
+
+	ca, cb := New(a), New(b)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	patch := Diff(ca, cb)
+	runtime.ReadMemStats(&after)
+
+	// An O((N+M)D) trace would allocate around a gigabyte here
+	if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 64<<20 {
+		t.Fatalf("expected at most %v bytes allocated, got %v", 64<<20, alloc)
+	}
+
+	edits := 0
+	for _, h := range patch {
+		if h.Op != Equal {
+			edits += len(h.Values)
+		}
+	}
+
+	if want := 2 * (n - n/1000); edits != want {
+		t.Fatalf("expected %v edits, got %v", want, edits)
+	}
+
+	err := ca.Apply(patch)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(items(ca), b)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Cursor_Apply(t *testing.T) {
+	a := New([]int{1, 2, 3}, Cap[int](3), History[int](0))
+	patch := Diff(a, New([]int{4, 5, 6}))
+
+	// Replacing every element must not go past the capacity part way
+	err := a.Apply(patch)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(items(a), []int{4, 5, 6})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if a.Cap() != 3 {
+		t.Fatalf("expected %v, got %v", 3, a.Cap())
+	}
+
+	// The whole patch is undone in one step
+	_ = a.Undo()
+
+	diff = cmp.Diff(items(a), []int{1, 2, 3})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	err = a.Apply(Diff(a, New([]int{1, 2, 3, 4})))
+	if err != ErrOverflow {
+		t.Fatalf("expected %v, got %v", ErrOverflow, err)
+	}
+
+	err = New([]int{1, 2}).Apply(patch)
+	if err != ErrPatchMismatch {
+		t.Fatalf("expected %v, got %v", ErrPatchMismatch, err)
+	}
+}
+
+func Test_Unified(t *testing.T) {
+	from := strings.Split("a\nb\nc\nd\ne\nf\ng\nh\ni\nj", "\n")
+	to := strings.Split("a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk", "\n")
+
+	got := Unified(Diff(New(from), New(to)), "old.txt", "new.txt", 2)
+
+	want := `--- old.txt
++++ new.txt
+@@ -1,4 +1,4 @@
+ a
+-b
++B
+ c
+ d
+@@ -9,2 +9,3 @@
+ i
+ j
++k
+`
+
+	if got != want {
+		t.Fatalf("expected\n%s\ngot\n%s", want, got)
+	}
+
+	// Changes close enough to share context are joined into one hunk
+	got = Unified(Diff(New(from), New(to)), "old.txt", "new.txt", 4)
+	if strings.Count(got, "@@ -") != 1 || !strings.Contains(got, "@@ -1,10 +1,11 @@") {
+		t.Fatalf("expected a single hunk, got\n%s", got)
+	}
+
+	got = Unified(Diff(New([]string{}), New([]string{"x"})), "a", "b", 3)
+	if !strings.Contains(got, "@@ -0,0 +1 @@\n+x\n") {
+		t.Fatalf("expected an insert into an empty file, got\n%s", got)
+	}
+
+	if got := Unified(Diff(New(from), New(from)), "a", "b", 3); got != "" {
+		t.Fatalf("expected no output, got\n%s", got)
+	}
+}
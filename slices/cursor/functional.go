@@ -0,0 +1,196 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"iter"
+)
+
+// The transforms in this file read the elements from the current position
+// of the cursor to the end of the buffer, in the same way as Rem, without
+// moving the cursor. Seek to the first element to transform the whole
+// buffer. Transforms which keep the element type return cursors stored in
+// the same kind of buffer as the source, with the same capacity and
+// comparator, while transforms to a new type take the options of the new
+// cursor.
+//
+// The Seq variants are lazy equivalents which take and return iterators so
+// that a chain of transforms runs without building intermediate buffers.
+// Pass the result to Collect to build a cursor from it.
+
+// Pair holds the elements of two cursors at the same offset, as produced
+// by Zip
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Map returns a new cursor holding the result of fn for each remaining
+// element of the cursor
+func Map[T, U any](c *Cursor[T], fn func(T) U, opts ...Option[U]) *Cursor[U] {
+	return Collect(MapSeq(c.values(), fn), opts...)
+}
+
+// Filter returns a new cursor holding the remaining elements of the
+// cursor for which keep returns true
+func Filter[T any](c *Cursor[T], keep func(T) bool) *Cursor[T] {
+	var out []T
+	for v := range FilterSeq(c.values(), keep) {
+		out = append(out, v)
+	}
+
+	return c.from(out)
+}
+
+// Reduce folds the remaining elements of the cursor into a single value,
+// starting from init
+func Reduce[T, U any](c *Cursor[T], init U, fn func(U, T) U) U {
+	acc := init
+	for v := range c.values() {
+		acc = fn(acc, v)
+	}
+
+	return acc
+}
+
+// Partition splits the remaining elements of the cursor into two new
+// cursors, the first holding the elements for which pred returns true and
+// the second holding the rest. Both keep the order of the source.
+func Partition[T any](c *Cursor[T], pred func(T) bool) (*Cursor[T], *Cursor[T]) {
+	var in, out []T
+	for v := range c.values() {
+		if pred(v) {
+			in = append(in, v)
+		} else {
+			out = append(out, v)
+		}
+	}
+
+	return c.from(in), c.from(out)
+}
+
+// GroupBy splits the remaining elements of the cursor into a new cursor
+// for each key returned by fn, keeping the order of the source within each
+// group
+func GroupBy[T any, K comparable](c *Cursor[T], fn func(T) K) map[K]*Cursor[T] {
+	groups := make(map[K][]T)
+	for v := range c.values() {
+		k := fn(v)
+		groups[k] = append(groups[k], v)
+	}
+
+	out := make(map[K]*Cursor[T], len(groups))
+	for k, values := range groups {
+		out[k] = c.from(values)
+	}
+
+	return out
+}
+
+// Zip returns a new cursor pairing the remaining elements of a and b,
+// which is as long as the shorter of the two
+func Zip[A, B any](a *Cursor[A], b *Cursor[B], opts ...Option[Pair[A, B]]) *Cursor[Pair[A, B]] {
+	return Collect(ZipSeq(a.values(), b.values()), opts...)
+}
+
+// FlatMap returns a new cursor holding the elements returned by fn for
+// each remaining element of the cursor, in order
+func FlatMap[T, U any](c *Cursor[T], fn func(T) []U, opts ...Option[U]) *Cursor[U] {
+	return Collect(FlatMapSeq(c.values(), fn), opts...)
+}
+
+// Collect returns a new cursor holding every element of the iterator
+func Collect[T any](seq iter.Seq[T], opts ...Option[T]) *Cursor[T] {
+	var out []T
+	for v := range seq {
+		out = append(out, v)
+	}
+
+	return New(out, opts...)
+}
+
+// MapSeq returns an iterator over the result of fn for each element of seq
+func MapSeq[T, U any](seq iter.Seq[T], fn func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			if !yield(fn(v)) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq returns an iterator over the elements of seq for which keep
+// returns true
+func FilterSeq[T any](seq iter.Seq[T], keep func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if keep(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ZipSeq returns an iterator pairing the elements of a and b, which stops
+// when either of them is exhausted
+func ZipSeq[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq[Pair[A, B]] {
+	return func(yield func(Pair[A, B]) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+
+		for va := range a {
+			vb, ok := next()
+			if !ok || !yield(Pair[A, B]{First: va, Second: vb}) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMapSeq returns an iterator over the elements returned by fn for each
+// element of seq, in order
+func FlatMapSeq[T, U any](seq iter.Seq[T], fn func(T) []U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range seq {
+			for _, u := range fn(v) {
+				if !yield(u) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// values returns an iterator over the elements from the current position
+// to the end of the buffer which, unlike Remaining, does not move the
+// cursor
+func (c *Cursor[T]) values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := max(c.pos, 0); i < c.store().Len(); i++ {
+			if !yield(c.store().At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// from returns a new cursor over the values stored in the same kind of
+// buffer, and with the same capacity and comparator, as the cursor
+func (c *Cursor[T]) from(values []T) *Cursor[T] {
+	out := c.derive(0, 0)
+	out.buff = c.backend(values)
+	return out
+}
@@ -0,0 +1,200 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func isEven(v int) bool {
+	return v%2 == 0
+}
+
+func Test_Map(t *testing.T) {
+	c := New([]int{1, 2, 3, 4})
+	_, _ = c.Seek(1)
+
+	got := Map(c, strconv.Itoa)
+
+	diff := cmp.Diff(items(got), []string{"2", "3", "4"})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if c.Pos() != 1 {
+		t.Fatalf("expected %v, got %v", 1, c.Pos())
+	}
+}
+
+func Test_Filter(t *testing.T) {
+	tests := []struct {
+		data []int
+		pos  int
+		want []int
+	}{
+		{[]int{1, 2, 3, 4, 5, 6}, 0, []int{2, 4, 6}},
+		{[]int{1, 2, 3, 4, 5, 6}, 4, []int{6}},
+		{[]int{1, 3, 5}, 0, []int{}},
+		{nil, 0, []int{}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			c := New(tt.data, WithStorage(GapStorage[int]), Cap[int](10))
+			c.pos = tt.pos
+
+			got := Filter(c, isEven)
+
+			diff := cmp.Diff(items(got), tt.want)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			if got.Cap() != 10 {
+				t.Fatalf("expected %v, got %v", 10, got.Cap())
+			}
+		})
+	}
+}
+
+func Test_Reduce(t *testing.T) {
+	c := New([]int{1, 2, 3, 4})
+
+	sum := Reduce(c, 0, func(acc, v int) int {
+		return acc + v
+	})
+
+	if sum != 10 {
+		t.Fatalf("expected %v, got %v", 10, sum)
+	}
+
+	joined := Reduce(c, "", func(acc string, v int) string {
+		return acc + strconv.Itoa(v)
+	})
+
+	if joined != "1234" {
+		t.Fatalf("expected %q, got %q", "1234", joined)
+	}
+}
+
+func Test_Partition(t *testing.T) {
+	c := New([]int{1, 2, 3, 4, 5})
+
+	even, odd := Partition(c, isEven)
+
+	diff := cmp.Diff(items(even), []int{2, 4})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff(items(odd), []int{1, 3, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_GroupBy(t *testing.T) {
+	c := New([]string{"a", "bb", "c", "dd", "eee"})
+
+	groups := GroupBy(c, func(s string) int {
+		return len(s)
+	})
+
+	want := map[int][]string{
+		1: {"a", "c"},
+		2: {"bb", "dd"},
+		3: {"eee"},
+	}
+
+	if len(groups) != len(want) {
+		t.Fatalf("expected %v, got %v", len(want), len(groups))
+	}
+
+	for k, values := range want {
+		diff := cmp.Diff(items(groups[k]), values)
+		if diff != "" {
+			t.Fatalf(diff)
+		}
+	}
+}
+
+func Test_Zip(t *testing.T) {
+	a := New([]int{1, 2, 3})
+	b := New([]string{"a", "b"})
+
+	got := Zip(a, b)
+
+	want := []Pair[int, string]{
+		{First: 1, Second: "a"},
+		{First: 2, Second: "b"},
+	}
+
+	diff := cmp.Diff(items(got), want)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_FlatMap(t *testing.T) {
+	c := New([]int{1, 2, 3})
+
+	got := FlatMap(c, func(v int) []int {
+		return slices.Repeat([]int{v}, v)
+	})
+
+	diff := cmp.Diff(items(got), []int{1, 2, 2, 3, 3, 3})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Seq_Chain(t *testing.T) {
+	c := New([]int{1, 2, 3, 4, 5, 6})
+
+	seq := MapSeq(FilterSeq(c.values(), isEven), func(v int) string {
+		return strconv.Itoa(v * 10)
+	})
+
+	got := Collect(ZipSeq(seq, slices.Values([]int{1, 2, 3, 4})))
+
+	want := []Pair[string, int]{
+		{First: "20", Second: 1},
+		{First: "40", Second: 2},
+		{First: "60", Second: 3},
+	}
+
+	diff := cmp.Diff(items(got), want)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// Stopping early must stop pulling from the source
+	count := 0
+	for range FlatMapSeq(c.Remaining(), func(v int) []int { return []int{v, v} }) {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+
+	if c.Pos() != 1 {
+		t.Fatalf("expected %v, got %v", 1, c.Pos())
+	}
+}
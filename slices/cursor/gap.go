@@ -0,0 +1,144 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+// minGap is the smallest gap allocated when a gap buffer grows
+const minGap = 64
+
+// gapStorage holds the elements in a single slice with a gap of unused
+// space at the last edit. Edits at the gap only touch the gap itself, so
+// repeated edits around the same position are amortized O(1), while an
+// edit elsewhere first moves the gap in O(distance) time.
+type gapStorage[T any] struct {
+	data []T
+
+	// start and end are the bounds of the gap within data
+	start int
+	end   int
+}
+
+// GapBuffer stores the elements of the cursor in a gap buffer, making
+// inserts and deletes at or near the same position amortized O(1) instead
+// of O(n). It suits editing workloads which are concentrated around the
+// cursor position.
+func GapBuffer[T any]() Option[T] {
+	return WithStorage(GapStorage[T])
+}
+
+// GapStorage returns storage holding the elements in a gap buffer
+func GapStorage[T any](data []T) Storage[T] {
+	// Start with the gap at the end of the elements
+	return &gapStorage[T]{
+		data:  data,
+		start: len(data),
+		end:   len(data),
+	}
+}
+
+func (b *gapStorage[T]) gap() int {
+	return b.end - b.start
+}
+
+// index maps a logical index to its index in data
+func (b *gapStorage[T]) index(i int) int {
+	if i < b.start {
+		return i
+	}
+
+	return i + b.gap()
+}
+
+func (b *gapStorage[T]) Len() int {
+	return len(b.data) - b.gap()
+}
+
+func (b *gapStorage[T]) At(i int) T {
+	return b.data[b.index(i)]
+}
+
+func (b *gapStorage[T]) Set(i int, v T) {
+	b.data[b.index(i)] = v
+}
+
+func (b *gapStorage[T]) View(start, end int) ([]T, bool) {
+	if start < b.start && end > b.start {
+		return nil, false
+	}
+
+	// The section is entirely before or after the gap
+	i, j := b.index(start), b.index(start)+end-start
+	return b.data[i:j:j], true
+}
+
+func (b *gapStorage[T]) Slice(start, end int) []T {
+	out := make([]T, end-start)
+
+	// Copy the elements before the gap and then those after it
+	n := 0
+	if start < b.start {
+		n = copy(out, b.data[start:min(end, b.start)])
+	}
+
+	i := b.index(start + n)
+	copy(out[n:], b.data[i:i+len(out)-n])
+
+	return out
+}
+
+func (b *gapStorage[T]) Splice(start, end int, values []T) {
+	b.move(start)
+
+	// Deleting grows the gap over the removed elements
+	clear(b.data[b.end : b.end+end-start])
+	b.end += end - start
+
+	b.grow(len(values))
+	b.start += copy(b.data[b.start:], values)
+}
+
+// move shifts the gap so that it starts at logical index i
+func (b *gapStorage[T]) move(i int) {
+	switch {
+	case i < b.start:
+		n := b.start - i
+		copy(b.data[b.end-n:b.end], b.data[i:b.start])
+		clear(b.data[i:min(b.start, b.end-n)])
+		b.start -= n
+		b.end -= n
+	case i > b.start:
+		n := i - b.start
+		copy(b.data[b.start:], b.data[b.end:b.end+n])
+		clear(b.data[max(b.start+n, b.end) : b.end+n])
+		b.start += n
+		b.end += n
+	}
+}
+
+// grow ensures the gap can hold at least n elements
+func (b *gapStorage[T]) grow(n int) {
+	if b.gap() >= n {
+		return
+	}
+
+	size := b.Len()
+	gap := max(n, size, minGap)
+
+	data := make([]T, size+gap)
+	copy(data, b.data[:b.start])
+	copy(data[b.start+gap:], b.data[b.end:])
+
+	b.data = data
+	b.end = b.start + gap
+}
@@ -0,0 +1,183 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_GapBuffer_Splice(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	var want []int
+	b := GapStorage[int](nil)
+
+	for i := 0; i < 5_000; i++ {
+		start := rng.Intn(len(want) + 1)
+		end := start + rng.Intn(len(want)-start+1)
+
+		values := make([]int, rng.Intn(minGap*2))
+		for j := range values {
+			values[j] = i*1000 + j
+		}
+
+		want = slices.Replace(want, start, end, values...)
+		b.Splice(start, end, values)
+
+		if b.Len() != len(want) {
+			t.Fatalf("expected %v, got %v", len(want), b.Len())
+		}
+
+		// Check a random slice which may straddle the gap
+		s := rng.Intn(len(want) + 1)
+		e := s + rng.Intn(len(want)-s+1)
+
+		diff := cmp.Diff(b.Slice(s, e), want[s:e])
+		if diff != "" {
+			t.Fatalf(diff)
+		}
+	}
+
+	for i, v := range want {
+		if b.At(i) != v {
+			t.Fatalf("expected %v, got %v", v, b.At(i))
+		}
+	}
+}
+
+func Test_GapBuffer_Clear(t *testing.T) {
+	v1, v2, v3 := 1, 2, 3
+	b := GapStorage([]*int{&v1, &v2, &v3}).(*gapStorage[*int])
+
+	b.Splice(1, 2, nil)
+	b.Splice(0, 0, []*int{&v2})
+	b.Splice(2, 2, nil)
+
+	// Every slot in the gap must be cleared so it does not keep the
+	// removed values alive
+	for _, p := range b.data[b.start:b.end] {
+		if p != nil {
+			t.Fatalf("expected %v, got %v", nil, *p)
+		}
+	}
+}
+
+func Test_Cursor_GapBuffer(t *testing.T) {
+	tests := []struct {
+		edit func(c *Cursor[int])
+		want []int
+	}{
+		{func(c *Cursor[int]) { _ = c.Insert(10, 20) }, []int{10, 20, 1, 2, 3, 4, 5}},
+		{func(c *Cursor[int]) { _ = c.InsertAt(3, 10) }, []int{1, 2, 3, 10, 4, 5}},
+		{func(c *Cursor[int]) { c.DeleteAt(2) }, []int{1, 2, 4, 5}},
+		{func(c *Cursor[int]) { _ = c.Chop(1, 4) }, []int{1, 5}},
+		{func(c *Cursor[int]) { c.Append(6) }, []int{1, 2, 3, 4, 5, 6}},
+		{func(c *Cursor[int]) { c.Prepend(0) }, []int{0, 1, 2, 3, 4, 5}},
+		{func(c *Cursor[int]) { c.Swap(0, 4) }, []int{5, 2, 3, 4, 1}},
+		{func(c *Cursor[int]) {
+			_, _ = c.Seek(2)
+			c.Set(30)
+			c.Delete()
+			_ = c.Insert(31, 32)
+			_ = c.InsertAt(0, 0)
+		}, []int{0, 1, 2, 31, 32, 4, 5}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			c := New([]int{1, 2, 3, 4, 5}, GapBuffer[int]())
+			tt.edit(c)
+
+			diff := cmp.Diff(items(c), tt.want)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			if _, ok := c.Copy().buff.(*gapStorage[int]); !ok {
+				t.Fatal("expected copies to keep the gap buffer")
+			}
+		})
+	}
+}
+
+// benchmarkBackends runs the benchmark against a cursor using each of the
+// storage backends
+func benchmarkBackends(b *testing.B, fn func(b *testing.B, opts ...Option[int])) {
+	b.Run("slice", func(b *testing.B) { fn(b) })
+	b.Run("gap", func(b *testing.B) { fn(b, GapBuffer[int]()) })
+	b.Run("ring", func(b *testing.B) { fn(b, WithStorage(RingStorage[int])) })
+	b.Run("chunked", func(b *testing.B) { fn(b, WithStorage(ChunkedStorage[int])) })
+}
+
+func Benchmark_Cursor_InsertAtPos(b *testing.B) {
+	benchmarkBackends(b, func(b *testing.B, opts ...Option[int]) {
+		c := New(make([]int, 100_000), opts...)
+		_, _ = c.Seek(50_000)
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = c.Insert(i)
+			_, _ = c.Next()
+		}
+	})
+}
+
+func Benchmark_Cursor_DeleteAtPos(b *testing.B) {
+	benchmarkBackends(b, func(b *testing.B, opts ...Option[int]) {
+		c := New(make([]int, 100_000+b.N), opts...)
+		_, _ = c.Seek(50_000)
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			c.Delete()
+		}
+	})
+}
+
+func Benchmark_Cursor_Typing(b *testing.B) {
+	benchmarkBackends(b, func(b *testing.B, opts ...Option[int]) {
+		c := New(make([]int, 100_000), opts...)
+		_, _ = c.Seek(50_000)
+
+		// Type a few characters, backspace one and move on
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = c.Insert(i, i, i)
+			c.DeleteAt(c.Pos() + 2)
+			_, _ = c.Seek(c.Pos() + 2)
+		}
+	})
+}
+
+func Benchmark_Cursor_Iterate(b *testing.B) {
+	benchmarkBackends(b, func(b *testing.B, opts ...Option[int]) {
+		c := New(make([]int, 100_000), opts...)
+		_, _ = c.Seek(50_000)
+		_ = c.Insert(1)
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			sum := 0
+			for _, v := range c.All() {
+				sum += v
+			}
+		}
+	})
+}
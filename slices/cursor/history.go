@@ -0,0 +1,203 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"errors"
+	"slices"
+)
+
+var ErrNoHistory = errors.New("no history")
+
+// edit is a single reversible change to the buffer where the elements in
+// removed, starting at start, were replaced by the elements in inserted.
+type edit[T any] struct {
+	start    int
+	removed  []T
+	inserted []T
+}
+
+// change is one step of the history made up of every edit applied by a
+// single method call or group, along with the cursor position before and
+// after it.
+type change[T any] struct {
+	edits  []edit[T]
+	before int
+	after  int
+}
+
+type history[T any] struct {
+	depth int
+	undo  []*change[T]
+	redo  []*change[T]
+
+	// group is the change collecting edits while a Group is running
+	group *change[T]
+
+	// replaying is set while an undo or redo is applied so that the
+	// edits are not recorded again
+	replaying bool
+}
+
+// History enables undo and redo for the cursor, keeping up to depth steps.
+// A depth of zero or less keeps every step.
+func History[T any](depth int) Option[T] {
+	return func(c *Cursor[T]) {
+		c.hist = &history[T]{depth: depth}
+	}
+}
+
+// record adds the replacement of the elements from start to end with the
+// given values to the history. It must be called before the buffer is
+// modified.
+func (c *Cursor[T]) record(start, end int, values []T) {
+	h := c.hist
+	if h == nil || h.replaying {
+		return
+	}
+
+	e := edit[T]{
+		start:    start,
+		removed:  c.store().Slice(start, end),
+		inserted: slices.Clone(values),
+	}
+
+	if h.group != nil {
+		h.group.edits = append(h.group.edits, e)
+		return
+	}
+
+	h.push(&change[T]{edits: []edit[T]{e}, before: c.pos})
+}
+
+// push adds the change to the undo stack, clearing the redo stack and
+// dropping the oldest step if the history is full.
+func (h *history[T]) push(ch *change[T]) {
+	h.undo = append(h.undo, ch)
+	h.redo = nil
+
+	if h.depth > 0 && len(h.undo) > h.depth {
+		h.undo = slices.Delete(h.undo, 0, len(h.undo)-h.depth)
+	}
+}
+
+// clone returns a copy of the history without any open group. Each change
+// is copied as Undo records the cursor position on it, while the edits are
+// never modified and are shared.
+func (h *history[T]) clone() *history[T] {
+	if h == nil {
+		return nil
+	}
+
+	return &history[T]{
+		depth: h.depth,
+		undo:  cloneChanges(h.undo),
+		redo:  cloneChanges(h.redo),
+	}
+}
+
+func cloneChanges[T any](changes []*change[T]) []*change[T] {
+	out := make([]*change[T], len(changes))
+	for i, ch := range changes {
+		cp := *ch
+		out[i] = &cp
+	}
+
+	return out
+}
+
+// Group calls fn and records every edit it makes as a single step of the
+// history, so one Undo reverts all of them. Groups may be nested, in which
+// case the edits belong to the outermost group. The edits made by fn are
+// kept even if it returns an error.
+func (c *Cursor[T]) Group(fn func() error) error {
+	h := c.hist
+	if h == nil || h.group != nil {
+		return fn()
+	}
+
+	h.group = &change[T]{before: c.pos}
+	defer func() {
+		if len(h.group.edits) > 0 {
+			h.push(h.group)
+		}
+
+		h.group = nil
+	}()
+
+	return fn()
+}
+
+// CanUndo reports whether there is a step to undo
+func (c *Cursor[T]) CanUndo() bool {
+	return c.hist != nil && len(c.hist.undo) > 0
+}
+
+// CanRedo reports whether there is an undone step to redo
+func (c *Cursor[T]) CanRedo() bool {
+	return c.hist != nil && len(c.hist.redo) > 0
+}
+
+// Undo reverts the most recent step of the history and restores the
+// cursor position from before it. ErrNoHistory is returned if history is
+// not enabled or there is nothing to undo.
+func (c *Cursor[T]) Undo() error {
+	if !c.CanUndo() || c.hist.group != nil {
+		return ErrNoHistory
+	}
+
+	h := c.hist
+	ch := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+
+	// Remember where the cursor was so Redo can put it back
+	ch.after = c.pos
+
+	h.replaying = true
+	for i := len(ch.edits) - 1; i >= 0; i-- {
+		e := ch.edits[i]
+		c.splice(e.start, e.start+len(e.inserted), e.removed)
+	}
+	h.replaying = false
+
+	c.move(ch.before)
+	h.redo = append(h.redo, ch)
+
+	return nil
+}
+
+// Redo reapplies the most recently undone step of the history.
+// ErrNoHistory is returned if history is not enabled or there is nothing
+// to redo.
+func (c *Cursor[T]) Redo() error {
+	if !c.CanRedo() || c.hist.group != nil {
+		return ErrNoHistory
+	}
+
+	h := c.hist
+	ch := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+
+	h.replaying = true
+	for _, e := range ch.edits {
+		c.splice(e.start, e.start+len(e.removed), e.inserted)
+	}
+	h.replaying = false
+
+	c.move(ch.after)
+	h.undo = append(h.undo, ch)
+
+	return nil
+}
@@ -0,0 +1,298 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Cursor_Undo(t *testing.T) {
+	tests := []struct {
+		pos  int
+		edit func(c *Cursor[int])
+		want []int
+	}{
+		{1, func(c *Cursor[int]) { c.Set(10) }, []int{1, 10, 3, 4, 5}},
+		{1, func(c *Cursor[int]) { _ = c.Insert(10, 20) }, []int{1, 10, 20, 2, 3, 4, 5}},
+		{0, func(c *Cursor[int]) { _ = c.InsertAt(3, 10) }, []int{1, 2, 3, 10, 4, 5}},
+		{2, func(c *Cursor[int]) { c.Delete() }, []int{1, 2, 4, 5}},
+		{0, func(c *Cursor[int]) { c.DeleteAt(4) }, []int{1, 2, 3, 4}},
+		{0, func(c *Cursor[int]) { _ = c.Chop(1, 4) }, []int{1, 5}},
+		{0, func(c *Cursor[int]) { c.Append(6, 7) }, []int{1, 2, 3, 4, 5, 6, 7}},
+		{2, func(c *Cursor[int]) { c.Prepend(-1, 0) }, []int{-1, 0, 1, 2, 3, 4, 5}},
+		{0, func(c *Cursor[int]) { c.Swap(0, 3) }, []int{4, 2, 3, 1, 5}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			original := []int{1, 2, 3, 4, 5}
+
+			c := New(original, History[int](0))
+			c.pos = tt.pos
+
+			tt.edit(c)
+			after := c.pos
+
+			diff := cmp.Diff(items(c), tt.want)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			if err := c.Undo(); err != nil {
+				t.Fatalf("expected %v, got %v", nil, err)
+			}
+
+			diff = cmp.Diff(items(c), original)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			if c.pos != tt.pos {
+				t.Fatalf("expected %v, got %v", tt.pos, c.pos)
+			}
+
+			if err := c.Redo(); err != nil {
+				t.Fatalf("expected %v, got %v", nil, err)
+			}
+
+			diff = cmp.Diff(items(c), tt.want)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			if c.pos != after {
+				t.Fatalf("expected %v, got %v", after, c.pos)
+			}
+		})
+	}
+}
+
+func Test_Cursor_Undo_Replace(t *testing.T) {
+	c := New([]int{1, 2, 3, 4, 5}, History[int](0))
+	c.pos = 1
+
+	out, err := c.Replace(10, 20)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(items(c), []int{1, 2, 3, 4, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if c.CanUndo() {
+		t.Fatal("expected the original cursor to be unchanged")
+	}
+
+	if err := out.Undo(); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff = cmp.Diff(items(out), []int{1, 2, 3, 4, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if err := out.Redo(); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff = cmp.Diff(items(out), []int{1, 10, 20, 4, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Cursor_Undo_Sequence(t *testing.T) {
+	c := New([]int{1, 2, 3}, History[int](0))
+
+	c.Append(4)
+	c.DeleteAt(0)
+	c.Set(10)
+
+	states := [][]int{
+		{2, 3, 4},
+		{1, 2, 3, 4},
+		{1, 2, 3},
+	}
+
+	for _, want := range states {
+		if err := c.Undo(); err != nil {
+			t.Fatalf("expected %v, got %v", nil, err)
+		}
+
+		diff := cmp.Diff(items(c), want)
+		if diff != "" {
+			t.Fatalf(diff)
+		}
+	}
+
+	if err := c.Undo(); err != ErrNoHistory {
+		t.Fatalf("expected %v, got %v", ErrNoHistory, err)
+	}
+
+	_ = c.Redo()
+	_ = c.Redo()
+
+	// A new edit discards the steps which could be redone
+	c.Prepend(0)
+
+	if c.CanRedo() {
+		t.Fatal("expected nothing to redo")
+	}
+
+	diff := cmp.Diff(items(c), []int{0, 2, 3, 4})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Cursor_Group(t *testing.T) {
+	c := New([]int{1, 2, 3, 4, 5}, History[int](0))
+
+	errFailed := errors.New("failed")
+
+	err := c.Group(func() error {
+		c.Set(10)
+		_ = c.InsertAt(2, 20, 30)
+
+		return c.Group(func() error {
+			_ = c.Chop(4, 6)
+			c.Append(40)
+			return errFailed
+		})
+	})
+	if err != errFailed {
+		t.Fatalf("expected %v, got %v", errFailed, err)
+	}
+
+	diff := cmp.Diff(items(c), []int{10, 2, 20, 30, 5, 40})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if len(c.hist.undo) != 1 {
+		t.Fatalf("expected %v, got %v", 1, len(c.hist.undo))
+	}
+
+	_ = c.Undo()
+
+	diff = cmp.Diff(items(c), []int{1, 2, 3, 4, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	_ = c.Redo()
+
+	diff = cmp.Diff(items(c), []int{10, 2, 20, 30, 5, 40})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// Empty groups are not recorded
+	_ = c.Group(func() error { return nil })
+
+	if len(c.hist.undo) != 1 {
+		t.Fatalf("expected %v, got %v", 1, len(c.hist.undo))
+	}
+}
+
+func Test_Cursor_History_Depth(t *testing.T) {
+	c := New([]int{}, History[int](2))
+
+	c.Append(1)
+	c.Append(2)
+	c.Append(3)
+
+	_ = c.Undo()
+	_ = c.Undo()
+
+	if err := c.Undo(); err != ErrNoHistory {
+		t.Fatalf("expected %v, got %v", ErrNoHistory, err)
+	}
+
+	diff := cmp.Diff(items(c), []int{1})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Cursor_History_Disabled(t *testing.T) {
+	c := New([]int{1, 2, 3})
+	c.Append(4)
+
+	if err := c.Undo(); err != ErrNoHistory {
+		t.Fatalf("expected %v, got %v", ErrNoHistory, err)
+	}
+
+	if err := c.Redo(); err != ErrNoHistory {
+		t.Fatalf("expected %v, got %v", ErrNoHistory, err)
+	}
+
+	err := c.Group(func() error {
+		c.Append(5)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+}
+
+func Test_Cursor_Undo_Marks(t *testing.T) {
+	c := New([]int{1, 2, 3, 4, 5}, History[int](0))
+	m, _ := c.Mark(3)
+
+	_ = c.Chop(0, 2)
+	if m.Pos() != 1 {
+		t.Fatalf("expected %v, got %v", 1, m.Pos())
+	}
+
+	_ = c.Undo()
+	if m.Pos() != 3 {
+		t.Fatalf("expected %v, got %v", 3, m.Pos())
+	}
+}
+
+func Test_Cursor_Undo_Clone(t *testing.T) {
+	c := New([]int{1, 2, 3, 4, 5}, History[int](0))
+	_ = c.InsertAt(1, 10)
+	_, _ = c.Seek(4)
+
+	out, err := c.ReplaceAt(0, 0)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	_ = c.Undo()
+	_ = out.Undo()
+
+	// Undoing the copy from a different position must not change where
+	// the original returns to on Redo
+	_, _ = out.Seek(1)
+	_ = out.Undo()
+
+	if err := c.Redo(); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if c.Pos() != 4 {
+		t.Fatalf("expected %v, got %v", 4, c.Pos())
+	}
+}
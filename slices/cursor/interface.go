@@ -0,0 +1,113 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"iter"
+)
+
+// Interface describes the contract shared by the cursors in this package.
+// C is the concrete cursor type returned by the methods which derive a new
+// cursor, such as Take and Copy, so that callers keep the concrete type.
+type Interface[T, C any] interface {
+	// Len returns the number of elements under the cursor
+	Len() int
+
+	// Pos returns the position of the cursor
+	Pos() int
+
+	// Cap returns the maximum number of elements the cursor can hold
+	Cap() int
+
+	// Available returns the number of elements which can still be added
+	Available() int
+
+	// Seek moves the cursor to pos and returns the element there
+	Seek(pos int) (T, error)
+
+	// Next moves the cursor forward and returns the element there
+	Next() (T, error)
+
+	// Prev moves the cursor back and returns the element there
+	Prev() (T, error)
+
+	// Get returns the element at the cursor
+	Get() (T, error)
+
+	// Set replaces the element at the cursor
+	Set(v T)
+
+	// First moves the cursor to the first element and returns it
+	First() (T, error)
+
+	// Last moves the cursor to the last element and returns it
+	Last() (T, error)
+
+	// Slice returns a copy of the elements from start to end
+	Slice(start, end int) ([]T, error)
+
+	// Rem returns a copy of the elements from the cursor to the end
+	Rem() []T
+
+	// Take returns the next i elements and a cursor over the rest
+	Take(i int) ([]T, C, error)
+
+	// Skip returns a cursor over the elements after the next i
+	Skip(i int) (C, error)
+
+	// Copy returns an independent copy of the cursor
+	Copy() C
+
+	// Replace returns a copy with the elements from the cursor replaced
+	Replace(values ...T) (C, error)
+
+	// ReplaceAt returns a copy with the elements from pos replaced
+	ReplaceAt(pos int, values ...T) (C, error)
+
+	// Insert inserts the values at the cursor
+	Insert(values ...T) error
+
+	// InsertAt inserts the values at pos
+	InsertAt(pos int, values ...T) error
+
+	// Delete removes the element at the cursor
+	Delete()
+
+	// DeleteAt removes the element at pos
+	DeleteAt(pos int)
+
+	// Chop removes the elements from start to end
+	Chop(start, end int) error
+
+	// Append adds the values to the end
+	Append(values ...T) error
+
+	// Prepend adds the values to the start
+	Prepend(values ...T) error
+
+	// All iterates over every element, moving the cursor as it goes
+	All() iter.Seq2[int, T]
+
+	// Forward iterates from the cursor to the end
+	Forward() iter.Seq2[int, T]
+
+	// Backward iterates from the cursor to the start
+	Backward() iter.Seq2[int, T]
+}
+
+var (
+	_ Interface[int, *Cursor[int]] = (*Cursor[int])(nil)
+	_ Interface[int, *Sync[int]]   = (*Sync[int])(nil)
+)
@@ -0,0 +1,139 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"iter"
+)
+
+// All returns an iterator over every index and element of the cursor
+// starting from the first element. The cursor position follows the
+// iteration so that after an early exit it rests on the last element
+// yielded.
+func (c *Cursor[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; c.isValidPOS(i); i++ {
+			c.move(i)
+			if !yield(i, c.store().At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Forward returns an iterator over the index and element of the cursor
+// from the current position to the end of the buffer, advancing the
+// cursor as it goes. Like IterFn the cursor rests on the last element
+// once the iteration completes.
+func (c *Cursor[T]) Forward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := c.pos; c.isValidPOS(i); i++ {
+			c.move(i)
+			if !yield(i, c.store().At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the index and element of the cursor
+// from the current position back to the start of the buffer, moving the
+// cursor as it goes.
+func (c *Cursor[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := c.pos; c.isValidPOS(i); i-- {
+			c.move(i)
+			if !yield(i, c.store().At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Remaining returns an iterator over the elements from the current
+// position to the end of the buffer, advancing the cursor as it goes.
+// It is the iterator equivalent of Rem.
+func (c *Cursor[T]) Remaining() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range c.Forward() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Window returns an iterator over every run of n consecutive elements
+// from the current position to the end of the buffer, along with the
+// index of the first element of each run, advancing the cursor to the
+// start of each run as it goes.
+//
+// To avoid allocating on every step the window shares memory with the
+// buffer where the storage allows it and otherwise reuses a single slice.
+// The window is only valid until the next iteration and must not be
+// modified; copy it to keep it.
+func (c *Cursor[T]) Window(n int) iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		if n <= 0 {
+			return
+		}
+
+		var buf []T
+		for i := c.pos; i >= 0 && i+n <= c.store().Len(); i++ {
+			c.move(i)
+
+			buf = view(c.store(), i, i+n, buf)
+			if !yield(i, buf) {
+				return
+			}
+		}
+	}
+}
+
+// Chunks returns an iterator over consecutive batches of n elements from
+// the current position to the end of the buffer, along with the index of
+// the first element of each batch, advancing the cursor to the start of
+// each batch as it goes. The last batch holds the remaining elements and
+// may be shorter than n. Batches share memory in the same way as Window.
+func (c *Cursor[T]) Chunks(n int) iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		if n <= 0 {
+			return
+		}
+
+		var buf []T
+		for i := c.pos; c.isValidPOS(i); i += n {
+			c.move(i)
+
+			buf = view(c.store(), i, min(i+n, c.store().Len()), buf)
+			if !yield(i, buf) {
+				return
+			}
+		}
+	}
+}
+
+// Peek returns an iterator over the index and element of up to n elements
+// from the current position without moving the cursor.
+func (c *Cursor[T]) Peek(n int) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		start := c.pos
+		for i := start; i < start+n && c.isValidPOS(i); i++ {
+			if !yield(i, c.store().At(i)) {
+				return
+			}
+		}
+	}
+}
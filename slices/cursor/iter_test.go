@@ -0,0 +1,290 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Cursor_All(t *testing.T) {
+	c := New([]int{1, 2, 3, 4, 5})
+	c.pos = 3
+
+	var idx, got []int
+	for i, v := range c.All() {
+		idx = append(idx, i)
+		got = append(got, v)
+	}
+
+	diff := cmp.Diff(idx, []int{0, 1, 2, 3, 4})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff(got, []int{1, 2, 3, 4, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if c.pos != 4 {
+		t.Fatalf("expected %v, got %v", 4, c.pos)
+	}
+}
+
+func Test_Cursor_Forward(t *testing.T) {
+	tests := []struct {
+		data  []int
+		pos   int
+		stop  int
+		want  []int
+		final int
+	}{
+		{[]int{1, 2, 3, 4, 5}, 0, -1, []int{1, 2, 3, 4, 5}, 4},
+		{[]int{1, 2, 3, 4, 5}, 2, -1, []int{3, 4, 5}, 4},
+		{[]int{1, 2, 3, 4, 5}, 4, -1, []int{5}, 4},
+		{[]int{1, 2, 3, 4, 5}, 1, 3, []int{2, 3, 4}, 3},
+		{[]int{1, 2, 3, 4, 5}, 5, -1, nil, 5},
+		{[]int{}, 0, -1, nil, 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			c := New(tt.data)
+			c.pos = tt.pos
+
+			var got []int
+			for i, v := range c.Forward() {
+				got = append(got, v)
+				if i == tt.stop {
+					break
+				}
+			}
+
+			diff := cmp.Diff(got, tt.want)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			if c.pos != tt.final {
+				t.Fatalf("expected %v, got %v", tt.final, c.pos)
+			}
+		})
+	}
+}
+
+func Test_Cursor_Backward(t *testing.T) {
+	tests := []struct {
+		data  []int
+		pos   int
+		stop  int
+		want  []int
+		final int
+	}{
+		{[]int{1, 2, 3, 4, 5}, 4, -1, []int{5, 4, 3, 2, 1}, 0},
+		{[]int{1, 2, 3, 4, 5}, 2, -1, []int{3, 2, 1}, 0},
+		{[]int{1, 2, 3, 4, 5}, 0, -1, []int{1}, 0},
+		{[]int{1, 2, 3, 4, 5}, 4, 2, []int{5, 4, 3}, 2},
+		{[]int{}, 0, -1, nil, 0},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			c := New(tt.data)
+			c.pos = tt.pos
+
+			var got []int
+			for i, v := range c.Backward() {
+				got = append(got, v)
+				if i == tt.stop {
+					break
+				}
+			}
+
+			diff := cmp.Diff(got, tt.want)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			if c.pos != tt.final {
+				t.Fatalf("expected %v, got %v", tt.final, c.pos)
+			}
+		})
+	}
+}
+
+func Test_Cursor_Remaining(t *testing.T) {
+	c := New([]int{1, 2, 3, 4, 5})
+	c.pos = 2
+
+	got := slices.Collect(c.Remaining())
+
+	diff := cmp.Diff(got, []int{3, 4, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if c.pos != 4 {
+		t.Fatalf("expected %v, got %v", 4, c.pos)
+	}
+}
+
+// iterBackends lists the storage backends the view iterators are tested
+// against, covering both the shared and the copied windows
+var iterBackends = map[string]Option[int]{
+	"slice":   WithStorage(SliceStorage[int]),
+	"gap":     GapBuffer[int](),
+	"ring":    WithStorage(RingStorage[int]),
+	"chunked": WithStorage(ChunkedStorage[int]),
+}
+
+func Test_Cursor_Window(t *testing.T) {
+	tests := []struct {
+		pos  int
+		n    int
+		want [][]int
+	}{
+		{0, 3, [][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}},
+		{2, 3, [][]int{{3, 4, 5}}},
+		{3, 3, nil},
+		{0, 5, [][]int{{1, 2, 3, 4, 5}}},
+		{0, 6, nil},
+		{0, 0, nil},
+	}
+
+	for name, opt := range iterBackends {
+		for i, tt := range tests {
+			t.Run(fmt.Sprintf("%s_%v", name, i), func(t *testing.T) {
+				c := New([]int{1, 2, 3, 4, 5}, opt)
+
+				// Move the gap of a gap buffer into the middle
+				_ = c.InsertAt(2, 0)
+				c.DeleteAt(2)
+				c.pos = tt.pos
+
+				var got [][]int
+				for start, w := range c.Window(tt.n) {
+					if c.Pos() != start {
+						t.Fatalf("expected %v, got %v", start, c.Pos())
+					}
+
+					got = append(got, slices.Clone(w))
+				}
+
+				diff := cmp.Diff(got, tt.want)
+				if diff != "" {
+					t.Fatalf(diff)
+				}
+			})
+		}
+	}
+}
+
+func Test_Cursor_Chunks(t *testing.T) {
+	tests := []struct {
+		pos  int
+		n    int
+		want [][]int
+	}{
+		{0, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{1, 2, [][]int{{2, 3}, {4, 5}}},
+		{0, 5, [][]int{{1, 2, 3, 4, 5}}},
+		{0, 9, [][]int{{1, 2, 3, 4, 5}}},
+		{0, 0, nil},
+	}
+
+	for name, opt := range iterBackends {
+		for i, tt := range tests {
+			t.Run(fmt.Sprintf("%s_%v", name, i), func(t *testing.T) {
+				c := New([]int{1, 2, 3, 4, 5}, opt)
+				c.pos = tt.pos
+
+				var got [][]int
+				for start, chunk := range c.Chunks(tt.n) {
+					if c.Pos() != start {
+						t.Fatalf("expected %v, got %v", start, c.Pos())
+					}
+
+					got = append(got, slices.Clone(chunk))
+				}
+
+				diff := cmp.Diff(got, tt.want)
+				if diff != "" {
+					t.Fatalf(diff)
+				}
+			})
+		}
+	}
+}
+
+func Test_Cursor_Peek(t *testing.T) {
+	c := New([]int{1, 2, 3, 4, 5})
+	c.pos = 2
+
+	var idx, got []int
+	for i, v := range c.Peek(2) {
+		idx = append(idx, i)
+		got = append(got, v)
+	}
+
+	diff := cmp.Diff(got, []int{3, 4})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff(idx, []int{2, 3})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if c.pos != 2 {
+		t.Fatalf("expected %v, got %v", 2, c.pos)
+	}
+
+	count := 0
+	for range c.Peek(10) {
+		count++
+	}
+
+	if count != 3 {
+		t.Fatalf("expected %v, got %v", 3, count)
+	}
+}
+
+func Test_Cursor_Window_Allocs(t *testing.T) {
+	for name, opt := range iterBackends {
+		t.Run(name, func(t *testing.T) {
+			c := New(make([]int, 1000), opt)
+
+			allocs := testing.AllocsPerRun(10, func() {
+				c.pos = 0
+
+				sum := 0
+				for _, w := range c.Window(8) {
+					sum += w[0]
+				}
+			})
+
+			// The iterator itself and at most one reused buffer, rather than
+			// one allocation per window
+			if allocs > 10 {
+				t.Fatalf("expected at most %v allocations, got %v", 10, allocs)
+			}
+		})
+	}
+}
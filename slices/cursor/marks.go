@@ -0,0 +1,124 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+// Mark is a position in the buffer of a cursor which is adjusted by every
+// edit made through the cursor so that it keeps pointing at the same
+// element. Elements inserted at the position of a mark are placed before
+// it, and a mark inside a deleted section moves to the start of the
+// section.
+type Mark struct {
+	pos int
+
+	// left marks stay before elements inserted at their position
+	left bool
+}
+
+// Pos returns the current position of the mark
+func (m *Mark) Pos() int {
+	return m.pos
+}
+
+// adjust moves the mark to account for the elements from start to end
+// being replaced by n elements.
+func (m *Mark) adjust(start, end, n int) {
+	switch {
+	case m.pos < start, m.left && m.pos == start:
+	case m.pos >= end:
+		m.pos += n - (end - start)
+	default:
+		m.pos = min(m.pos, start+n)
+	}
+}
+
+// Range is a section of the buffer of a cursor, such as a selection, which
+// grows and shrinks as the elements inside it are edited. Elements inserted
+// at either end of the range become part of it.
+type Range struct {
+	start *Mark
+	end   *Mark
+}
+
+// Start returns the position of the first element of the range
+func (r *Range) Start() int {
+	return r.start.pos
+}
+
+// End returns the position after the last element of the range
+func (r *Range) End() int {
+	return r.end.pos
+}
+
+// Len returns the number of elements in the range
+func (r *Range) Len() int {
+	return r.end.pos - r.start.pos
+}
+
+// Mark registers a mark at the given position, which may be any index of
+// the buffer or its length to mark the end of the buffer.
+func (c *Cursor[T]) Mark(pos int) (*Mark, error) {
+	if pos < 0 || pos > c.store().Len() {
+		return nil, ErrIndexOutOfRange
+	}
+
+	m := &Mark{pos: pos}
+	c.marks = append(c.marks, m)
+
+	return m, nil
+}
+
+// MarkRange registers a range over the elements from start to end
+func (c *Cursor[T]) MarkRange(start, end int) (*Range, error) {
+	if start < 0 || start > end || end > c.store().Len() {
+		return nil, ErrIndexOutOfRange
+	}
+
+	r := &Range{
+		start: &Mark{pos: start, left: true},
+		end:   &Mark{pos: end},
+	}
+	c.marks = append(c.marks, r.start, r.end)
+
+	return r, nil
+}
+
+// Unmark stops the mark from being adjusted by the cursor
+func (c *Cursor[T]) Unmark(m *Mark) {
+	for i, mark := range c.marks {
+		if mark == m {
+			c.marks = append(c.marks[:i], c.marks[i+1:]...)
+			return
+		}
+	}
+}
+
+// UnmarkRange stops the range from being adjusted by the cursor
+func (c *Cursor[T]) UnmarkRange(r *Range) {
+	c.Unmark(r.start)
+	c.Unmark(r.end)
+}
+
+// SeekMark moves the cursor to the mark and returns the element there
+func (c *Cursor[T]) SeekMark(m *Mark) (T, error) {
+	return c.Seek(m.pos)
+}
+
+// shiftMarks adjusts every registered mark for the elements from start to
+// end being replaced by n elements.
+func (c *Cursor[T]) shiftMarks(start, end, n int) {
+	for _, m := range c.marks {
+		m.adjust(start, end, n)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"fmt"
+	"testing"
+)
+
+func Test_Cursor_Mark(t *testing.T) {
+	tests := []struct {
+		mark int
+		edit func(c *Cursor[int])
+		want int
+	}{
+		{2, func(c *Cursor[int]) { _ = c.InsertAt(0, 10, 20) }, 4},
+		{2, func(c *Cursor[int]) { _ = c.InsertAt(2, 10, 20) }, 4},
+		{2, func(c *Cursor[int]) { _ = c.InsertAt(3, 10, 20) }, 2},
+		{2, func(c *Cursor[int]) { c.DeleteAt(0) }, 1},
+		{2, func(c *Cursor[int]) { c.DeleteAt(2) }, 2},
+		{2, func(c *Cursor[int]) { c.DeleteAt(3) }, 2},
+		{2, func(c *Cursor[int]) { _ = c.Chop(0, 2) }, 0},
+		{2, func(c *Cursor[int]) { _ = c.Chop(1, 4) }, 1},
+		{2, func(c *Cursor[int]) { _ = c.Chop(3, 4) }, 2},
+		{2, func(c *Cursor[int]) { c.Prepend(10, 20, 30) }, 5},
+		{2, func(c *Cursor[int]) { c.Append(10, 20, 30) }, 2},
+		{5, func(c *Cursor[int]) { c.Append(10, 20, 30) }, 8},
+		{2, func(c *Cursor[int]) { c.Set(10) }, 2},
+		{2, func(c *Cursor[int]) {
+			c.pos = 1
+			_ = c.Insert(10)
+			c.Delete()
+			c.Delete()
+		}, 1},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			c := New([]int{1, 2, 3, 4, 5})
+
+			m, err := c.Mark(tt.mark)
+			if err != nil {
+				t.Fatalf("expected %v, got %v", nil, err)
+			}
+
+			tt.edit(c)
+
+			if m.Pos() != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, m.Pos())
+			}
+		})
+	}
+}
+
+func Test_Cursor_Mark_Element(t *testing.T) {
+	c := New([]int{1, 2, 3, 4, 5})
+
+	m, _ := c.Mark(3)
+
+	c.Prepend(-1, 0)
+	_ = c.InsertAt(3, 10, 20)
+	c.DeleteAt(0)
+	_ = c.Chop(0, 2)
+
+	got, err := c.SeekMark(m)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if got != 4 {
+		t.Fatalf("expected %v, got %v", 4, got)
+	}
+}
+
+func Test_Cursor_Mark_Invalid(t *testing.T) {
+	c := New([]int{1, 2, 3})
+
+	for _, pos := range []int{-1, 4} {
+		_, err := c.Mark(pos)
+		if err != ErrIndexOutOfRange {
+			t.Fatalf("expected %v, got %v", ErrIndexOutOfRange, err)
+		}
+	}
+
+	for _, r := range [][2]int{{-1, 2}, {2, 1}, {0, 4}} {
+		_, err := c.MarkRange(r[0], r[1])
+		if err != ErrIndexOutOfRange {
+			t.Fatalf("expected %v, got %v", ErrIndexOutOfRange, err)
+		}
+	}
+}
+
+func Test_Cursor_MarkRange(t *testing.T) {
+	tests := []struct {
+		start, end int
+		edit       func(c *Cursor[int])
+		want       [2]int
+	}{
+		// Inserting before, at the edges of, inside and after the range
+		{1, 3, func(c *Cursor[int]) { _ = c.InsertAt(0, 10) }, [2]int{2, 4}},
+		{1, 3, func(c *Cursor[int]) { _ = c.InsertAt(1, 10) }, [2]int{1, 4}},
+		{1, 3, func(c *Cursor[int]) { _ = c.InsertAt(2, 10, 20) }, [2]int{1, 5}},
+		{1, 3, func(c *Cursor[int]) { _ = c.InsertAt(3, 10) }, [2]int{1, 4}},
+		{1, 3, func(c *Cursor[int]) { _ = c.InsertAt(4, 10) }, [2]int{1, 3}},
+		{2, 2, func(c *Cursor[int]) { _ = c.InsertAt(2, 10, 20) }, [2]int{2, 4}},
+
+		// Deleting before, inside, across the edges of and after the range
+		{1, 3, func(c *Cursor[int]) { c.DeleteAt(0) }, [2]int{0, 2}},
+		{1, 3, func(c *Cursor[int]) { c.DeleteAt(1) }, [2]int{1, 2}},
+		{1, 3, func(c *Cursor[int]) { _ = c.Chop(0, 2) }, [2]int{0, 1}},
+		{1, 3, func(c *Cursor[int]) { _ = c.Chop(2, 4) }, [2]int{1, 2}},
+		{1, 3, func(c *Cursor[int]) { _ = c.Chop(0, 4) }, [2]int{0, 0}},
+		{1, 3, func(c *Cursor[int]) { c.DeleteAt(3) }, [2]int{1, 3}},
+
+		{1, 3, func(c *Cursor[int]) { c.Prepend(10) }, [2]int{2, 4}},
+		{1, 3, func(c *Cursor[int]) { c.Append(10) }, [2]int{1, 3}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			c := New([]int{1, 2, 3, 4, 5})
+
+			r, err := c.MarkRange(tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("expected %v, got %v", nil, err)
+			}
+
+			tt.edit(c)
+
+			got := [2]int{r.Start(), r.End()}
+			if got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+
+			if r.Len() != tt.want[1]-tt.want[0] {
+				t.Fatalf("expected %v, got %v", tt.want[1]-tt.want[0], r.Len())
+			}
+		})
+	}
+}
+
+func Test_Cursor_Unmark(t *testing.T) {
+	c := New([]int{1, 2, 3, 4, 5})
+
+	m, _ := c.Mark(2)
+	r, _ := c.MarkRange(1, 3)
+
+	c.Unmark(m)
+	c.UnmarkRange(r)
+	c.Prepend(10)
+
+	if m.Pos() != 2 {
+		t.Fatalf("expected %v, got %v", 2, m.Pos())
+	}
+
+	if r.Start() != 1 || r.End() != 3 {
+		t.Fatalf("expected %v, got %v", [2]int{1, 3}, [2]int{r.Start(), r.End()})
+	}
+
+	if len(c.marks) != 0 {
+		t.Fatalf("expected %v, got %v", 0, len(c.marks))
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"iter"
+	"slices"
+)
+
+// Matcher searches cursors for several patterns at once using the
+// Aho-Corasick algorithm, which takes O(n + matches) time however many
+// patterns there are. When several patterns match, the match which starts
+// first wins, and of those the longest.
+type Matcher[T comparable] struct {
+	patterns [][]T
+	forward  *automaton[T]
+	backward *automaton[T]
+}
+
+// NewMatcher creates a new Matcher for the patterns. Empty patterns never
+// match.
+func NewMatcher[T comparable](patterns ...[]T) *Matcher[T] {
+	reversed := make([][]T, len(patterns))
+	for i, p := range patterns {
+		reversed[i] = slices.Clone(p)
+		slices.Reverse(reversed[i])
+	}
+
+	return &Matcher[T]{
+		patterns: patterns,
+		forward:  newAutomaton(patterns),
+		backward: newAutomaton(reversed),
+	}
+}
+
+// Find searches for the first match of any pattern starting at or after
+// the position of the cursor and seeks the cursor to the start of it. If
+// there is no match it returns ErrNotFound and the cursor is left where it
+// was.
+func (m *Matcher[T]) Find(c *Cursor[T]) (Match, error) {
+	return m.find(c, c.pos)
+}
+
+// FindNext is like Find but only matches which start after the position
+// of the cursor, so repeated calls step through every match.
+func (m *Matcher[T]) FindNext(c *Cursor[T]) (Match, error) {
+	return m.find(c, c.pos+1)
+}
+
+// FindPrev searches backwards for the last match of any pattern which
+// starts before the position of the cursor and seeks the cursor to the
+// start of it.
+func (m *Matcher[T]) FindPrev(c *Cursor[T]) (Match, error) {
+	n := c.store().Len()
+	if c.pos <= 0 || m.backward.longest == 0 {
+		return Match{}, ErrNotFound
+	}
+
+	// A match starts before the cursor when it ends in the reversed buffer
+	// after the reversed position of the cursor, so the first such match
+	// found scanning the reversed buffer is the last match before it
+	limit := n - min(c.pos, n)
+	from := max(0, limit-m.backward.longest+1)
+
+	state := 0
+	for i := from; i < n; i++ {
+		state = m.backward.step(state, c.store().At(n-1-i))
+		if i+1 <= limit {
+			continue
+		}
+
+		// Outputs are ordered longest first
+		if out := m.backward.out[state]; len(out) > 0 {
+			p := out[0]
+			match := Match{
+				Pattern: p,
+				Start:   n - 1 - i,
+				End:     n - 1 - i + len(m.patterns[p]),
+			}
+
+			c.move(match.Start)
+			return match, nil
+		}
+	}
+
+	return Match{}, ErrNotFound
+}
+
+// FindAll returns an iterator over the matches from the position of the
+// cursor to the end of the buffer, seeking the cursor to each match as it
+// is yielded. Matches do not overlap.
+func (m *Matcher[T]) FindAll(c *Cursor[T]) iter.Seq[Match] {
+	return func(yield func(Match) bool) {
+		for from := c.pos; ; {
+			match, err := m.find(c, from)
+			if err != nil || !yield(match) {
+				return
+			}
+
+			from = match.End
+		}
+	}
+}
+
+// find searches for the leftmost longest match starting at or after the
+// given index and seeks the cursor to it
+func (m *Matcher[T]) find(c *Cursor[T], from int) (Match, error) {
+	if from < 0 {
+		return Match{}, ErrNotFound
+	}
+
+	best := Match{Start: -1}
+
+	state := 0
+	for i := from; i < c.store().Len(); i++ {
+		// No match ending later can start before the best match so far
+		if best.Start >= 0 && i-m.forward.longest+1 > best.Start {
+			break
+		}
+
+		state = m.forward.step(state, c.store().At(i))
+		for _, p := range m.forward.out[state] {
+			start := i + 1 - len(m.patterns[p])
+			if best.Start < 0 || start < best.Start ||
+				(start == best.Start && i+1 > best.End) {
+				best = Match{Pattern: p, Start: start, End: i + 1}
+			}
+		}
+	}
+
+	if best.Start < 0 {
+		return Match{}, ErrNotFound
+	}
+
+	c.move(best.Start)
+	return best, nil
+}
+
+// automaton is an Aho-Corasick automaton over a set of patterns
+type automaton[T comparable] struct {
+	next []map[T]int
+	fail []int
+
+	// out holds the patterns matched on reaching each state, longest
+	// first
+	out [][]int
+
+	longest int
+}
+
+func newAutomaton[T comparable](patterns [][]T) *automaton[T] {
+	a := &automaton[T]{
+		next: []map[T]int{{}},
+		fail: []int{0},
+		out:  [][]int{nil},
+	}
+
+	// Build the trie of the patterns
+	for p, pattern := range patterns {
+		if len(pattern) == 0 {
+			continue
+		}
+
+		a.longest = max(a.longest, len(pattern))
+
+		state := 0
+		for _, v := range pattern {
+			child, ok := a.next[state][v]
+			if !ok {
+				child = len(a.next)
+				a.next = append(a.next, map[T]int{})
+				a.fail = append(a.fail, 0)
+				a.out = append(a.out, nil)
+				a.next[state][v] = child
+			}
+
+			state = child
+		}
+
+		a.out[state] = append(a.out[state], p)
+	}
+
+	// Link each state to the state of its longest proper suffix in breadth
+	// first order, so the suffix is always linked before the state
+	var queue []int
+	for _, child := range a.next[0] {
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+
+		for v, child := range a.next[state] {
+			queue = append(queue, child)
+			a.fail[child] = a.step(a.fail[state], v)
+		}
+
+		// The patterns of the suffix are shorter than those of the state
+		a.out[state] = append(a.out[state], a.out[a.fail[state]]...)
+	}
+
+	return a
+}
+
+// step returns the state reached from state on reading v
+func (a *automaton[T]) step(state int, v T) int {
+	for {
+		if next, ok := a.next[state][v]; ok {
+			return next
+		}
+
+		if state == 0 {
+			return 0
+		}
+
+		state = a.fail[state]
+	}
+}
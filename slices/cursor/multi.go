@@ -0,0 +1,214 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"slices"
+)
+
+// Multi holds several positions, or carets, over the buffer of a single
+// cursor and applies each edit at every caret at once, like multi-caret
+// editing. The carets are marks on the cursor, so they are adjusted for
+// every edit, including edits made directly through the cursor.
+//
+// Edits are applied at each caret in turn from the last caret to the
+// first, so the result does not depend on the order in which the carets
+// were added. Carets which end up at the same position are merged. When
+// history is enabled an edit at every caret is undone as a single step.
+type Multi[T any] struct {
+	c      *Cursor[T]
+	carets []*Mark
+}
+
+// NewMulti creates a new Multi over the cursor with a caret at each of the
+// positions, which may be any index of the buffer or its length.
+func NewMulti[T any](c *Cursor[T], positions ...int) (*Multi[T], error) {
+	m := &Multi[T]{c: c}
+	for _, pos := range positions {
+		if err := m.Add(pos); err != nil {
+			m.Close()
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// Cursor returns the cursor the carets are over
+func (m *Multi[T]) Cursor() *Cursor[T] {
+	return m.c
+}
+
+// Add adds a caret at pos, which may be any index of the buffer or its
+// length. Adding a caret where there already is one has no effect.
+func (m *Multi[T]) Add(pos int) error {
+	if slices.ContainsFunc(m.carets, func(c *Mark) bool { return c.pos == pos }) {
+		return nil
+	}
+
+	caret, err := m.c.Mark(pos)
+	if err != nil {
+		return err
+	}
+
+	m.carets = append(m.carets, caret)
+	m.sort()
+
+	return nil
+}
+
+// Remove removes the caret at pos, if there is one
+func (m *Multi[T]) Remove(pos int) {
+	m.carets = slices.DeleteFunc(m.carets, func(c *Mark) bool {
+		if c.pos != pos {
+			return false
+		}
+
+		m.c.Unmark(c)
+		return true
+	})
+}
+
+// Close removes every caret from the cursor so that it no longer has to
+// adjust them
+func (m *Multi[T]) Close() {
+	for _, c := range m.carets {
+		m.c.Unmark(c)
+	}
+
+	m.carets = nil
+}
+
+// Positions returns the positions of the carets in ascending order
+func (m *Multi[T]) Positions() []int {
+	out := make([]int, len(m.carets))
+	for i, c := range m.carets {
+		out[i] = c.pos
+	}
+
+	return out
+}
+
+// Move moves every caret by n, stopping at the start and end of the
+// buffer
+func (m *Multi[T]) Move(n int) {
+	for _, c := range m.carets {
+		c.pos = min(max(c.pos+n, 0), m.c.store().Len())
+	}
+
+	m.merge()
+}
+
+// Values returns the element at each caret in ascending order of the
+// carets, skipping carets at the end of the buffer
+func (m *Multi[T]) Values() []T {
+	var out []T
+	for _, c := range m.carets {
+		if m.c.isValidPOS(c.pos) {
+			out = append(out, m.c.store().At(c.pos))
+		}
+	}
+
+	return out
+}
+
+// Insert inserts the values at every caret, leaving each caret after the
+// values it inserted
+func (m *Multi[T]) Insert(values ...T) error {
+	if !m.c.fits(len(values) * len(m.carets)) {
+		return ErrOverflow
+	}
+
+	return m.each(func(pos int) {
+		m.c.splice(pos, pos, values)
+	})
+}
+
+// Delete deletes the element at every caret. Carets at the end of the
+// buffer are left alone.
+func (m *Multi[T]) Delete() error {
+	return m.each(func(pos int) {
+		if m.c.isValidPOS(pos) {
+			m.c.splice(pos, pos+1, nil)
+		}
+	})
+}
+
+// Set replaces the element at every caret with v. Carets at the end of
+// the buffer are left alone.
+func (m *Multi[T]) Set(v T) error {
+	return m.each(func(pos int) {
+		if m.c.isValidPOS(pos) {
+			m.c.splice(pos, pos+1, []T{v})
+		}
+	})
+}
+
+// Replace overwrites the elements from every caret onwards with the
+// values. If the values run past the end of the buffer from any caret it
+// returns ErrOverflow without changing anything. Where the sections
+// overlap, the caret nearest the start of the buffer is applied last and
+// so wins.
+func (m *Multi[T]) Replace(values ...T) error {
+	for _, c := range m.carets {
+		if c.pos+len(values) > m.c.store().Len() {
+			return ErrOverflow
+		}
+	}
+
+	return m.each(func(pos int) {
+		m.c.splice(pos, pos+len(values), values)
+	})
+}
+
+// each calls fn with the position of every caret from the last to the
+// first as a single step of the history, then merges any carets which
+// have met
+func (m *Multi[T]) each(fn func(pos int)) error {
+	err := m.c.Group(func() error {
+		for i := len(m.carets) - 1; i >= 0; i-- {
+			fn(m.carets[i].pos)
+		}
+
+		return nil
+	})
+
+	m.merge()
+	return err
+}
+
+func (m *Multi[T]) sort() {
+	slices.SortFunc(m.carets, func(a, b *Mark) int {
+		return a.pos - b.pos
+	})
+}
+
+// merge sorts the carets and removes any at the same position
+func (m *Multi[T]) merge() {
+	m.sort()
+
+	out := m.carets[:0]
+	for i, c := range m.carets {
+		if i > 0 && c.pos == out[len(out)-1].pos {
+			m.c.Unmark(c)
+			continue
+		}
+
+		out = append(out, c)
+	}
+
+	clear(m.carets[len(out):])
+	m.carets = out
+}
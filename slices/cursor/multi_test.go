@@ -0,0 +1,183 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Multi_Edits(t *testing.T) {
+	tests := []struct {
+		name      string
+		carets    []int
+		edit      func(m *Multi[rune]) error
+		want      string
+		positions []int
+		err       error
+	}{
+		{"insert", []int{0, 4, 8}, func(m *Multi[rune]) error {
+			return m.Insert('>', ' ')
+		}, "> foo > bar > baz", []int{2, 8, 14}, nil},
+		{"insert order independent", []int{8, 0, 4}, func(m *Multi[rune]) error {
+			return m.Insert('>', ' ')
+		}, "> foo > bar > baz", []int{2, 8, 14}, nil},
+		{"insert at end", []int{11}, func(m *Multi[rune]) error {
+			return m.Insert('!')
+		}, "foo bar baz!", []int{12}, nil},
+		{"delete", []int{0, 4, 8}, func(m *Multi[rune]) error {
+			return m.Delete()
+		}, "oo ar az", []int{0, 3, 6}, nil},
+		{"delete merges carets", []int{3, 4}, func(m *Multi[rune]) error {
+			if err := m.Delete(); err != nil {
+				return err
+			}
+			return m.Delete()
+		}, "foor baz", []int{3}, nil},
+		{"delete at end", []int{11}, func(m *Multi[rune]) error {
+			return m.Delete()
+		}, "foo bar baz", []int{11}, nil},
+		{"set", []int{0, 4, 8}, func(m *Multi[rune]) error {
+			return m.Set('X')
+		}, "Xoo Xar Xaz", []int{0, 4, 8}, nil},
+		{"replace", []int{0, 4, 8}, func(m *Multi[rune]) error {
+			return m.Replace('1', '2', '3')
+		}, "123 123 123", []int{0, 4, 8}, nil},
+		{"replace overlap", []int{0, 2}, func(m *Multi[rune]) error {
+			return m.Replace('1', '2', '3')
+		}, "12323ar baz", []int{0, 2}, nil},
+		{"replace overflow", []int{0, 9}, func(m *Multi[rune]) error {
+			return m.Replace('1', '2', '3')
+		}, "foo bar baz", []int{0, 9}, ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New([]rune("foo bar baz"))
+
+			m, err := NewMulti(c, tt.carets...)
+			if err != nil {
+				t.Fatalf("expected %v, got %v", nil, err)
+			}
+
+			err = tt.edit(m)
+			if err != tt.err {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			if got := string(items(c)); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+
+			diff := cmp.Diff(m.Positions(), tt.positions)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func Test_Multi_Carets(t *testing.T) {
+	c := New([]int{1, 2, 3, 4, 5})
+
+	if _, err := NewMulti(c, 1, 6); err != ErrIndexOutOfRange {
+		t.Fatalf("expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+
+	if len(c.marks) != 0 {
+		t.Fatalf("expected %v, got %v", 0, len(c.marks))
+	}
+
+	m, _ := NewMulti(c, 3, 1, 3)
+
+	diff := cmp.Diff(m.Positions(), []int{1, 3})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff(m.Values(), []int{2, 4})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// Edits made directly through the cursor move the carets too
+	_ = c.Prepend(0)
+
+	diff = cmp.Diff(m.Positions(), []int{2, 4})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	m.Move(-3)
+	diff = cmp.Diff(m.Positions(), []int{0, 1})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	m.Move(-1)
+	diff = cmp.Diff(m.Positions(), []int{0})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	m.Remove(0)
+	m.Close()
+
+	if len(c.marks) != 0 {
+		t.Fatalf("expected %v, got %v", 0, len(c.marks))
+	}
+}
+
+func Test_Multi_Undo(t *testing.T) {
+	c := New([]int{1, 2, 3}, History[int](0))
+	m, _ := NewMulti(c, 0, 1, 2)
+
+	if err := m.Insert(0); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(items(c), []int{0, 1, 0, 2, 0, 3})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if err := c.Undo(); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff = cmp.Diff(items(c), []int{1, 2, 3})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if c.CanUndo() {
+		t.Fatal("expected the edit to be a single step")
+	}
+}
+
+func Test_Multi_Cap(t *testing.T) {
+	c := New([]int{1, 2, 3}, Cap[int](5))
+	m, _ := NewMulti(c, 0, 1, 2)
+
+	if err := m.Insert(0); err != ErrOverflow {
+		t.Fatalf("expected %v, got %v", ErrOverflow, err)
+	}
+
+	diff := cmp.Diff(items(c), []int{1, 2, 3})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"slices"
+)
+
+// Event describes a change to a cursor. It is one of Inserted, Deleted,
+// Replaced or Moved.
+type Event interface {
+	isEvent()
+}
+
+// Inserted is sent when the values are inserted at index At
+type Inserted[T any] struct {
+	At     int
+	Values []T
+}
+
+// Deleted is sent when the elements from Start to End are deleted
+type Deleted struct {
+	Start int
+	End   int
+}
+
+// Replaced is sent when the elements from Start to End are replaced by the
+// values, which may be a different number of elements
+type Replaced[T any] struct {
+	Start  int
+	End    int
+	Values []T
+}
+
+// Moved is sent when the position of the cursor changes
+type Moved struct {
+	From int
+	To   int
+}
+
+func (Inserted[T]) isEvent() {}
+func (Deleted) isEvent()     {}
+func (Replaced[T]) isEvent() {}
+func (Moved) isEvent()       {}
+
+// Observe registers fn to be called with an Event after every change to
+// the contents or position of the cursor, including those made by undo
+// and redo. Observers are called synchronously in the order they were
+// registered and must not modify the cursor. They are not carried over to
+// cursors derived from the cursor, such as by Copy or Replace.
+func Observe[T any](fn func(e Event)) Option[T] {
+	return func(c *Cursor[T]) {
+		c.observers = append(c.observers, fn)
+	}
+}
+
+// notify sends the event to every observer
+func (c *Cursor[T]) notify(e Event) {
+	for _, fn := range c.observers {
+		fn(e)
+	}
+}
+
+// notifySplice sends the event describing the elements from start to end
+// being replaced by the values
+func (c *Cursor[T]) notifySplice(start, end int, values []T) {
+	if len(c.observers) == 0 {
+		return
+	}
+
+	switch {
+	case start == end && len(values) == 0:
+	case start == end:
+		c.notify(Inserted[T]{At: start, Values: slices.Clone(values)})
+	case len(values) == 0:
+		c.notify(Deleted{Start: start, End: end})
+	default:
+		c.notify(Replaced[T]{Start: start, End: end, Values: slices.Clone(values)})
+	}
+}
+
+// move moves the cursor to pos, notifying observers if it changes
+func (c *Cursor[T]) move(pos int) {
+	if pos == c.pos {
+		return
+	}
+
+	from := c.pos
+	c.pos = pos
+
+	if len(c.observers) > 0 {
+		c.notify(Moved{From: from, To: pos})
+	}
+}
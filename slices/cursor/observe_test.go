@@ -0,0 +1,176 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Cursor_Observe(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(c *Cursor[int])
+		want []Event
+	}{
+		{"insert", func(c *Cursor[int]) { _ = c.InsertAt(1, 7, 8) }, []Event{
+			Inserted[int]{At: 1, Values: []int{7, 8}},
+		}},
+		{"append", func(c *Cursor[int]) { _ = c.Append(7) }, []Event{
+			Inserted[int]{At: 3, Values: []int{7}},
+		}},
+		{"prepend", func(c *Cursor[int]) { _ = c.Prepend(7) }, []Event{
+			Inserted[int]{At: 0, Values: []int{7}},
+			Moved{From: 0, To: 1},
+		}},
+		{"delete", func(c *Cursor[int]) { c.DeleteAt(2) }, []Event{
+			Deleted{Start: 2, End: 3},
+		}},
+		{"chop", func(c *Cursor[int]) { _ = c.Chop(0, 2) }, []Event{
+			Deleted{Start: 0, End: 2},
+		}},
+		{"set", func(c *Cursor[int]) { c.Set(9) }, []Event{
+			Replaced[int]{Start: 0, End: 1, Values: []int{9}},
+		}},
+		{"seek", func(c *Cursor[int]) {
+			_, _ = c.Seek(2)
+			_, _ = c.Seek(2)
+			_, _ = c.Prev()
+			_, _ = c.Seek(5)
+		}, []Event{
+			Moved{From: 0, To: 2},
+			Moved{From: 2, To: 1},
+		}},
+		{"iterate", func(c *Cursor[int]) {
+			count := 0
+			for range c.All() {
+				count++
+			}
+		}, []Event{
+			Moved{From: 0, To: 1},
+			Moved{From: 1, To: 2},
+		}},
+		{"swap", func(c *Cursor[int]) { c.Swap(0, 2) }, []Event{
+			Replaced[int]{Start: 0, End: 1, Values: []int{2}},
+			Replaced[int]{Start: 2, End: 3, Values: []int{3}},
+		}},
+		{"sort", func(c *Cursor[int]) { _ = c.Sort() }, []Event{
+			Replaced[int]{Start: 0, End: 3, Values: []int{1, 2, 3}},
+		}},
+		{"replace copy", func(c *Cursor[int]) { _, _ = c.Replace(4) }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Event
+			c := New([]int{3, 1, 2}, Observe[int](func(e Event) {
+				got = append(got, e)
+			}))
+
+			tt.edit(c)
+
+			diff := cmp.Diff(got, tt.want)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func Test_Cursor_Observe_Undo(t *testing.T) {
+	var got []Event
+	c := New([]int{1, 2, 3}, History[int](0), Observe[int](func(e Event) {
+		got = append(got, e)
+	}))
+
+	_, _ = c.Seek(1)
+	c.Delete()
+	_ = c.Undo()
+
+	want := []Event{
+		Moved{From: 0, To: 1},
+		Deleted{Start: 1, End: 2},
+		Inserted[int]{At: 1, Values: []int{2}},
+	}
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+// Test_Cursor_Observe_Index keeps an index of the positions of the zeros
+// in the cursor in step with its contents from the events alone
+func Test_Cursor_Observe_Index(t *testing.T) {
+	var zeros []int
+
+	c := New([]int{}, Observe[int](func(e Event) {
+		var start, end int
+		var values []int
+
+		switch e := e.(type) {
+		case Inserted[int]:
+			start, end, values = e.At, e.At, e.Values
+		case Deleted:
+			start, end = e.Start, e.End
+		case Replaced[int]:
+			start, end, values = e.Start, e.End, e.Values
+		default:
+			return
+		}
+
+		var next []int
+		for _, z := range zeros {
+			switch {
+			case z < start:
+				next = append(next, z)
+			case z >= end:
+				next = append(next, z+len(values)-(end-start))
+			}
+		}
+
+		for i, v := range values {
+			if v == 0 {
+				next = append(next, start+i)
+			}
+		}
+
+		zeros = next
+	}))
+
+	_ = c.Append(1, 0, 2, 0)
+	_ = c.Prepend(0)
+	c.DeleteAt(2)
+	_ = c.InsertAt(1, 0, 5)
+	_, _ = c.Seek(3)
+	c.Set(0)
+
+	var want []int
+	for i, v := range items(c) {
+		if v == 0 {
+			want = append(want, i)
+		}
+	}
+
+	got := append([]int(nil), zeros...)
+	slices.Sort(got)
+
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"slices"
+)
+
+// Seq runs the parsers one after another and returns their values. If any
+// of them fails the position is restored.
+func Seq[T, R any](ps ...Parser[T, R]) Parser[T, []R] {
+	return func(s *State[T]) ([]R, error) {
+		start := s.pos
+
+		out := make([]R, 0, len(ps))
+		for _, p := range ps {
+			v, err := p(s)
+			if err != nil {
+				s.pos = start
+				return nil, err
+			}
+
+			out = append(out, v)
+		}
+
+		return out, nil
+	}
+}
+
+// Or tries each parser in turn from the same position and returns the
+// value of the first to succeed.
+func Or[T, R any](ps ...Parser[T, R]) Parser[T, R] {
+	return func(s *State[T]) (R, error) {
+		start := s.pos
+
+		for _, p := range ps {
+			v, err := p(s)
+			if err == nil {
+				return v, nil
+			}
+
+			s.pos = start
+		}
+
+		var zero R
+		return zero, s.err()
+	}
+}
+
+// Many runs the parser until it fails and returns the values parsed, which
+// may be none. It stops if the parser succeeds without consuming anything
+// so that it cannot loop forever.
+func Many[T, R any](p Parser[T, R]) Parser[T, []R] {
+	return func(s *State[T]) ([]R, error) {
+		out := []R{}
+		for {
+			start := s.pos
+
+			v, err := p(s)
+			if err != nil {
+				s.pos = start
+				return out, nil
+			}
+
+			out = append(out, v)
+			if s.pos == start {
+				return out, nil
+			}
+		}
+	}
+}
+
+// Optional runs the parser and returns the zero value of R, without
+// consuming anything, if it fails.
+func Optional[T, R any](p Parser[T, R]) Parser[T, R] {
+	return func(s *State[T]) (R, error) {
+		start := s.pos
+
+		v, err := p(s)
+		if err != nil {
+			s.pos = start
+
+			var zero R
+			return zero, nil
+		}
+
+		return v, nil
+	}
+}
+
+// SepBy parses zero or more values separated by sep and returns the
+// values. A trailing separator is not consumed.
+func SepBy[T, R, S any](p Parser[T, R], sep Parser[T, S]) Parser[T, []R] {
+	return func(s *State[T]) ([]R, error) {
+		out := []R{}
+
+		start := s.pos
+		v, err := p(s)
+		if err != nil {
+			s.pos = start
+			return out, nil
+		}
+
+		out = append(out, v)
+		for {
+			start = s.pos
+
+			if _, err = sep(s); err == nil {
+				v, err = p(s)
+			}
+
+			if err != nil {
+				s.pos = start
+				return out, nil
+			}
+
+			out = append(out, v)
+		}
+	}
+}
+
+// Map runs the parser and converts its value with fn
+func Map[T, R, U any](p Parser[T, R], fn func(R) U) Parser[T, U] {
+	return func(s *State[T]) (U, error) {
+		v, err := p(s)
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+
+		return fn(v), nil
+	}
+}
+
+// Lookahead runs the parser and returns its value without consuming any
+// input
+func Lookahead[T, R any](p Parser[T, R]) Parser[T, R] {
+	return func(s *State[T]) (R, error) {
+		start := s.pos
+		defer func() { s.pos = start }()
+
+		return p(s)
+	}
+}
+
+// Not succeeds without consuming any input if the parser fails, and fails
+// if it succeeds. The name describes what was not expected in error
+// messages.
+func Not[T, R any](name string, p Parser[T, R]) Parser[T, struct{}] {
+	return func(s *State[T]) (struct{}, error) {
+		start := s.pos
+
+		// Failures of the parser are what Not expects, so they must not
+		// show up in the error reported for the parse
+		furthest := s.furthest
+		furthest.Expected = slices.Clone(furthest.Expected)
+
+		_, err := p(s)
+		s.pos = start
+		s.furthest = furthest
+
+		if err == nil {
+			return struct{}{}, s.Fail("not " + name)
+		}
+
+		return struct{}{}, nil
+	}
+}
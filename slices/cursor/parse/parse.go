@@ -0,0 +1,191 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package parse provides parser combinators over the elements of a
+// cursor.Cursor. Every combinator which can fail part way through restores
+// the position it started from, so alternatives are tried without the
+// caller having to copy and roll back the cursor themselves.
+package parse
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.devnw.com/ds/slices/cursor"
+)
+
+// Error reports the furthest position any parser reached before failing,
+// along with what the parsers expected to find there.
+type Error struct {
+	Pos      int
+	Expected []string
+}
+
+func (e *Error) Error() string {
+	if len(e.Expected) == 0 {
+		return fmt.Sprintf("parse error at %d", e.Pos)
+	}
+
+	return fmt.Sprintf(
+		"parse error at %d: expected %s",
+		e.Pos,
+		strings.Join(e.Expected, " or "),
+	)
+}
+
+// State is the state of a parse which is threaded through the parsers.
+type State[T any] struct {
+	c   *cursor.Cursor[T]
+	pos int
+
+	// furthest is the failure which got the furthest into the input
+	furthest Error
+}
+
+// Pos returns the position of the next element to be parsed, which is the
+// length of the input once it has all been consumed
+func (s *State[T]) Pos() int {
+	return s.pos
+}
+
+// Peek returns the next element without consuming it. It returns false at
+// the end of the input.
+func (s *State[T]) Peek() (T, bool) {
+	v, err := s.c.At(s.pos)
+	return v, err == nil
+}
+
+// Fail returns an error for a parser which expected to find something at
+// the current position
+func (s *State[T]) Fail(expected string) error {
+	switch {
+	case s.pos > s.furthest.Pos:
+		s.furthest = Error{Pos: s.pos, Expected: []string{expected}}
+	case s.pos == s.furthest.Pos && !slices.Contains(s.furthest.Expected, expected):
+		s.furthest.Expected = append(s.furthest.Expected, expected)
+	}
+
+	return s.err()
+}
+
+// err returns a copy of the furthest failure
+func (s *State[T]) err() error {
+	return &Error{
+		Pos:      s.furthest.Pos,
+		Expected: slices.Clone(s.furthest.Expected),
+	}
+}
+
+// Parser parses a value of type R from the elements of a cursor.
+type Parser[T, R any] func(s *State[T]) (R, error)
+
+// Parse runs the parser from the position of the cursor and returns the
+// value parsed along with the position after the input it consumed. The
+// parsers read the input without moving the cursor. On success the cursor
+// is then moved to the position after the input if it is within the
+// buffer, and otherwise left where it was. On failure the cursor is left
+// where it was and the error is an *Error for the furthest position
+// reached.
+func Parse[T, R any](c *cursor.Cursor[T], p Parser[T, R]) (R, int, error) {
+	start := c.Pos()
+	s := &State[T]{
+		c:        c,
+		pos:      start,
+		furthest: Error{Pos: start},
+	}
+
+	out, err := p(s)
+	if err != nil {
+		var zero R
+		return zero, start, s.err()
+	}
+
+	// Seek leaves the cursor where it is if the position is out of range
+	_, _ = c.Seek(s.pos)
+
+	return out, s.pos, nil
+}
+
+// ParseAll is like Parse but fails unless the parser consumes all of the
+// input from the position of the cursor.
+func ParseAll[T, R any](c *cursor.Cursor[T], p Parser[T, R]) (R, error) {
+	out, _, err := Parse(c, func(s *State[T]) (R, error) {
+		out, err := p(s)
+		if err != nil {
+			return out, err
+		}
+
+		_, err = EOF[T]()(s)
+		return out, err
+	})
+
+	return out, err
+}
+
+// Satisfy parses a single element which satisfies the predicate. The name
+// describes the element in error messages.
+func Satisfy[T any](name string, pred func(T) bool) Parser[T, T] {
+	return func(s *State[T]) (T, error) {
+		v, ok := s.Peek()
+		if !ok || !pred(v) {
+			var zero T
+			return zero, s.Fail(name)
+		}
+
+		s.pos++
+		return v, nil
+	}
+}
+
+// Equal parses a single element equal to v
+func Equal[T comparable](v T) Parser[T, T] {
+	return Satisfy(describe(v), func(e T) bool {
+		return e == v
+	})
+}
+
+// describe returns the name of an element for use in error messages,
+// quoting runes, bytes and strings
+func describe(v any) string {
+	switch v := v.(type) {
+	case rune:
+		return strconv.QuoteRune(v)
+	case byte:
+		return strconv.QuoteRune(rune(v))
+	case string:
+		return strconv.Quote(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// Any parses any single element
+func Any[T any]() Parser[T, T] {
+	return Satisfy("any element", func(T) bool {
+		return true
+	})
+}
+
+// EOF succeeds only at the end of the input
+func EOF[T any]() Parser[T, struct{}] {
+	return func(s *State[T]) (struct{}, error) {
+		if _, ok := s.Peek(); ok {
+			return struct{}{}, s.Fail("end of input")
+		}
+
+		return struct{}{}, nil
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"unicode"
+
+	"github.com/google/go-cmp/cmp"
+	"go.devnw.com/ds/slices/cursor"
+)
+
+func runes(s string) *cursor.Cursor[rune] {
+	return cursor.New([]rune(s))
+}
+
+var digit = Satisfy("digit", unicode.IsDigit)
+
+// number parses a run of one or more digits into an int
+var number = Map(
+	Seq(Map(digit, func(r rune) []rune { return []rune{r} }), Many(digit)),
+	func(parts [][]rune) int {
+		n, _ := strconv.Atoi(string(parts[0]) + string(parts[1]))
+		return n
+	},
+)
+
+// list parses a bracketed list of numbers such as [1,2,3]
+var list = Map(
+	Seq(
+		Map(Equal('['), func(rune) []int { return nil }),
+		SepBy(number, Equal(',')),
+		Map(Equal(']'), func(rune) []int { return nil }),
+	),
+	func(parts [][]int) []int { return parts[1] },
+)
+
+func Test_Parse_List(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []int
+		err   *Error
+	}{
+		{"[]", []int{}, nil},
+		{"[1]", []int{1}, nil},
+		{"[1,22,333]", []int{1, 22, 333}, nil},
+		{"[1,22,", nil, &Error{Pos: 6, Expected: []string{"digit"}}},
+		{"[1,x]", nil, &Error{Pos: 3, Expected: []string{"digit"}}},
+		{"[12a]", nil, &Error{Pos: 3, Expected: []string{"digit", "','", "']'"}}},
+		{"1]", nil, &Error{Pos: 0, Expected: []string{"'['"}}},
+		{"[1]x", nil, &Error{Pos: 3, Expected: []string{"end of input"}}},
+		{"[1", nil, &Error{Pos: 2, Expected: []string{"digit", "','", "']'"}}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			got, err := ParseAll(runes(tt.input), list)
+
+			if tt.err == nil {
+				if err != nil {
+					t.Fatalf("expected %v, got %v", nil, err)
+				}
+
+				diff := cmp.Diff(got, tt.want)
+				if diff != "" {
+					t.Fatalf(diff)
+				}
+
+				return
+			}
+
+			var perr *Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			diff := cmp.Diff(perr, tt.err)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func Test_Parse_Cursor(t *testing.T) {
+	c := runes("[1,2] rest")
+
+	got, end, err := Parse(c, list)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(got, []int{1, 2})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if end != 5 || c.Pos() != 5 {
+		t.Fatalf("expected %v, got %v and %v", 5, end, c.Pos())
+	}
+
+	// A failed parse leaves the cursor where it was
+	_, _, err = Parse(c, list)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if c.Pos() != 5 {
+		t.Fatalf("expected %v, got %v", 5, c.Pos())
+	}
+}
+
+func Test_Parse_Observe(t *testing.T) {
+	var events []cursor.Event
+	c := cursor.New([]rune("[1,2,3] rest"), cursor.Observe[rune](func(e cursor.Event) {
+		events = append(events, e)
+	}))
+
+	_, _, err := Parse(c, list)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	// Only the move past the input is sent, not one for each lookahead
+	diff := cmp.Diff(events, []cursor.Event{cursor.Moved{From: 0, To: 7}})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Parse_Or(t *testing.T) {
+	abc := Seq(Equal('a'), Equal('b'), Equal('c'))
+	abd := Seq(Equal('a'), Equal('b'), Equal('d'))
+
+	got, err := ParseAll(runes("abd"), Or(abc, abd))
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if string(got) != "abd" {
+		t.Fatalf("expected %q, got %q", "abd", string(got))
+	}
+
+	_, err = ParseAll(runes("abe"), Or(abc, abd))
+
+	want := "parse error at 2: expected 'c' or 'd'"
+	if err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got %v", want, err)
+	}
+}
+
+func Test_Parse_Optional(t *testing.T) {
+	sign := Optional(Equal('-'))
+	signed := Seq(sign, digit)
+
+	for input, want := range map[string]string{"-5": "-5", "5": "\x005"} {
+		got, err := ParseAll(runes(input), signed)
+		if err != nil {
+			t.Fatalf("expected %v, got %v", nil, err)
+		}
+
+		if string(got) != want {
+			t.Fatalf("expected %q, got %q", want, string(got))
+		}
+	}
+}
+
+func Test_Parse_Lookahead_Not(t *testing.T) {
+	letter := Satisfy("letter", unicode.IsLetter)
+
+	// A keyword must not be followed by another letter
+	keyword := Seq(
+		Equal('i'),
+		Equal('f'),
+		Map(Not("letter", letter), func(struct{}) rune { return 0 }),
+	)
+
+	_, end, err := Parse(runes("if x"), keyword)
+	if err != nil || end != 2 {
+		t.Fatalf("expected %v, got %v (%v)", 2, end, err)
+	}
+
+	_, _, err = Parse(runes("iffy"), keyword)
+	if err == nil || err.Error() != "parse error at 2: expected not letter" {
+		t.Fatalf("expected %q, got %v", "parse error at 2: expected not letter", err)
+	}
+
+	v, end, err := Parse(runes("ab"), Lookahead(letter))
+	if err != nil || v != 'a' || end != 0 {
+		t.Fatalf("expected %q at %v, got %q at %v (%v)", 'a', 0, v, end, err)
+	}
+}
+
+func Test_Parse_Many_NoProgress(t *testing.T) {
+	got, err := ParseAll(runes(""), Many(Optional(Equal('a'))))
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("expected %v, got %v", 1, len(got))
+	}
+}
@@ -0,0 +1,443 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"iter"
+)
+
+// Persistent is a cursor whose elements are never modified in place.
+// Every edit returns a new version of the cursor which shares all but
+// O(log n) of its structure with the version it was made from, so earlier
+// versions remain valid and unchanged and can be edited independently.
+//
+// The elements are held in a persistent AVL tree indexed by position, so
+// reads, edits, Take and Skip all take O(log n) time plus the number of
+// elements copied in or out. Moving the position of a version, by Seek,
+// Next or Prev, does not create a new version.
+type Persistent[T any] struct {
+	root *pnode[T]
+	pos  int
+}
+
+// NewPersistent creates a new persistent cursor over a copy of the data
+func NewPersistent[T any](data []T) *Persistent[T] {
+	return &Persistent[T]{root: pbuild(data)}
+}
+
+// version returns a new version over root at the same position
+func (p *Persistent[T]) version(root *pnode[T]) *Persistent[T] {
+	return &Persistent[T]{root: root, pos: p.pos}
+}
+
+func (p *Persistent[T]) isValidPOS(pos int) bool {
+	return pos >= 0 && pos < p.root.len()
+}
+
+// Len returns the number of elements in the cursor
+func (p *Persistent[T]) Len() int {
+	return p.root.len()
+}
+
+// Pos returns the position of the cursor
+func (p *Persistent[T]) Pos() int {
+	return p.pos
+}
+
+// Seek moves the cursor to pos and returns the element there
+func (p *Persistent[T]) Seek(pos int) (T, error) {
+	if !p.isValidPOS(pos) {
+		var out T
+		return out, ErrIndexOutOfRange
+	}
+
+	p.pos = pos
+	return p.root.at(pos), nil
+}
+
+func (p *Persistent[T]) Next() (T, error) {
+	return p.Seek(p.pos + 1)
+}
+
+func (p *Persistent[T]) Prev() (T, error) {
+	return p.Seek(p.pos - 1)
+}
+
+func (p *Persistent[T]) Get() (T, error) {
+	return p.Seek(p.pos)
+}
+
+func (p *Persistent[T]) First() (T, error) {
+	return p.Seek(0)
+}
+
+func (p *Persistent[T]) Last() (T, error) {
+	return p.Seek(p.root.len() - 1)
+}
+
+// Slice returns a new slice with the elements from start to end
+func (p *Persistent[T]) Slice(start, end int) ([]T, error) {
+	if !p.isValidPOS(start) || !p.isValidPOS(end) || end < start {
+		return nil, ErrIndexOutOfRange
+	}
+
+	return p.root.slice(start, end), nil
+}
+
+// Rem returns the elements from the cursor to the end
+func (p *Persistent[T]) Rem() []T {
+	return p.root.slice(min(p.pos, p.root.len()), p.root.len())
+}
+
+// Values returns every element of the cursor
+func (p *Persistent[T]) Values() []T {
+	return p.root.slice(0, p.root.len())
+}
+
+// Take returns the next i elements along with a cursor over the elements
+// after them
+func (p *Persistent[T]) Take(i int) ([]T, *Persistent[T], error) {
+	if i < 0 {
+		return nil, p, ErrIndexOutOfRange
+	}
+
+	if p.pos+i > p.root.len() {
+		return nil, p, ErrUnderflow
+	}
+
+	_, rem := psplit(p.root, p.pos+i)
+	return p.root.slice(p.pos, p.pos+i), &Persistent[T]{root: rem}, nil
+}
+
+// Skip returns a cursor over the elements after the next i
+func (p *Persistent[T]) Skip(i int) (*Persistent[T], error) {
+	if !p.isValidPOS(p.pos + i) {
+		return &Persistent[T]{}, ErrIndexOutOfRange
+	}
+
+	_, rem := psplit(p.root, p.pos+i)
+	return &Persistent[T]{root: rem}, nil
+}
+
+// Set returns a new version with the element at the cursor replaced
+func (p *Persistent[T]) Set(v T) (*Persistent[T], error) {
+	if !p.isValidPOS(p.pos) {
+		return p, ErrIndexOutOfRange
+	}
+
+	return p.version(p.root.set(p.pos, v)), nil
+}
+
+// Replace returns a new version with the elements from the cursor
+// replaced by the values
+func (p *Persistent[T]) Replace(values ...T) (*Persistent[T], error) {
+	return p.ReplaceAt(p.pos, values...)
+}
+
+// ReplaceAt returns a new version with the elements from pos replaced by
+// the values
+func (p *Persistent[T]) ReplaceAt(pos int, values ...T) (*Persistent[T], error) {
+	if !p.isValidPOS(pos) {
+		return p, ErrIndexOutOfRange
+	}
+
+	if pos+len(values) > p.root.len() {
+		return p, ErrOverflow
+	}
+
+	return p.version(p.splice(pos, pos+len(values), values)), nil
+}
+
+// Insert returns a new version with the values inserted at the cursor
+func (p *Persistent[T]) Insert(values ...T) (*Persistent[T], error) {
+	return p.InsertAt(p.pos, values...)
+}
+
+// InsertAt returns a new version with the values inserted at pos
+func (p *Persistent[T]) InsertAt(pos int, values ...T) (*Persistent[T], error) {
+	if !p.isValidPOS(pos) {
+		return p, ErrIndexOutOfRange
+	}
+
+	return p.version(p.splice(pos, pos, values)), nil
+}
+
+// Delete returns a new version without the element at the cursor
+func (p *Persistent[T]) Delete() (*Persistent[T], error) {
+	return p.DeleteAt(p.pos)
+}
+
+// DeleteAt returns a new version without the element at pos
+func (p *Persistent[T]) DeleteAt(pos int) (*Persistent[T], error) {
+	if !p.isValidPOS(pos) {
+		return p, ErrIndexOutOfRange
+	}
+
+	return p.version(p.splice(pos, pos+1, nil)), nil
+}
+
+// Chop returns a new version without the elements from start to end
+func (p *Persistent[T]) Chop(start, end int) (*Persistent[T], error) {
+	if !p.isValidPOS(start) || !p.isValidPOS(end) || end < start {
+		return p, ErrIndexOutOfRange
+	}
+
+	return p.version(p.splice(start, end, nil)), nil
+}
+
+// Append returns a new version with the values added to the end
+func (p *Persistent[T]) Append(values ...T) *Persistent[T] {
+	return p.version(pjoin2(p.root, pbuild(values)))
+}
+
+// Prepend returns a new version with the values added to the start, with
+// the cursor still on the same element
+func (p *Persistent[T]) Prepend(values ...T) *Persistent[T] {
+	out := p.version(pjoin2(pbuild(values), p.root))
+	out.pos += len(values)
+	return out
+}
+
+// All returns an iterator over every index and element of the cursor. It
+// does not move the cursor.
+func (p *Persistent[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		p.root.walk(func(v T) bool {
+			ok := yield(i, v)
+			i++
+			return ok
+		})
+	}
+}
+
+// splice returns a tree with the elements from start to end replaced by
+// the values
+func (p *Persistent[T]) splice(start, end int, values []T) *pnode[T] {
+	left, rest := psplit(p.root, start)
+	_, right := psplit(rest, end-start)
+
+	return pjoin2(pjoin2(left, pbuild(values)), right)
+}
+
+// pnode is a node of a persistent AVL tree ordered by position. Nodes are
+// never modified once created.
+type pnode[T any] struct {
+	left, right *pnode[T]
+	value       T
+	size        int
+	height      int
+}
+
+func newPNode[T any](left *pnode[T], v T, right *pnode[T]) *pnode[T] {
+	return &pnode[T]{
+		left:   left,
+		right:  right,
+		value:  v,
+		size:   left.len() + right.len() + 1,
+		height: max(left.depth(), right.depth()) + 1,
+	}
+}
+
+func (n *pnode[T]) len() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+func (n *pnode[T]) depth() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+func (n *pnode[T]) at(i int) T {
+	for {
+		l := n.left.len()
+		switch {
+		case i < l:
+			n = n.left
+		case i == l:
+			return n.value
+		default:
+			i -= l + 1
+			n = n.right
+		}
+	}
+}
+
+// set returns a tree with the element at i replaced, copying only the
+// path to it
+func (n *pnode[T]) set(i int, v T) *pnode[T] {
+	l := n.left.len()
+	switch {
+	case i < l:
+		return newPNode(n.left.set(i, v), n.value, n.right)
+	case i == l:
+		return newPNode(n.left, v, n.right)
+	default:
+		return newPNode(n.left, n.value, n.right.set(i-l-1, v))
+	}
+}
+
+// slice returns the elements from start to end
+func (n *pnode[T]) slice(start, end int) []T {
+	out := make([]T, 0, end-start)
+
+	var collect func(n *pnode[T], offset int)
+	collect = func(n *pnode[T], offset int) {
+		if n == nil || offset >= end || offset+n.size <= start {
+			return
+		}
+
+		l := n.left.len()
+		collect(n.left, offset)
+
+		if i := offset + l; i >= start && i < end {
+			out = append(out, n.value)
+		}
+
+		collect(n.right, offset+l+1)
+	}
+
+	collect(n, 0)
+	return out
+}
+
+// walk calls fn with each element in order until it returns false
+func (n *pnode[T]) walk(fn func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	return n.left.walk(fn) && fn(n.value) && n.right.walk(fn)
+}
+
+// pbuild returns a balanced tree holding the values
+func pbuild[T any](values []T) *pnode[T] {
+	if len(values) == 0 {
+		return nil
+	}
+
+	mid := len(values) / 2
+	return newPNode(pbuild(values[:mid]), values[mid], pbuild(values[mid+1:]))
+}
+
+func protateLeft[T any](n *pnode[T]) *pnode[T] {
+	r := n.right
+	return newPNode(newPNode(n.left, n.value, r.left), r.value, r.right)
+}
+
+func protateRight[T any](n *pnode[T]) *pnode[T] {
+	l := n.left
+	return newPNode(l.left, l.value, newPNode(l.right, n.value, n.right))
+}
+
+// pjoin returns a balanced tree of the elements of left, then v, then the
+// elements of right
+func pjoin[T any](left *pnode[T], v T, right *pnode[T]) *pnode[T] {
+	switch {
+	case left.depth() > right.depth()+1:
+		return pjoinRight(left, v, right)
+	case right.depth() > left.depth()+1:
+		return pjoinLeft(left, v, right)
+	default:
+		return newPNode(left, v, right)
+	}
+}
+
+// pjoinRight joins a shorter right tree onto the right spine of left
+func pjoinRight[T any](left *pnode[T], v T, right *pnode[T]) *pnode[T] {
+	if left.right.depth() <= right.depth()+1 {
+		t := newPNode(left.right, v, right)
+		if t.height <= left.left.depth()+1 {
+			return newPNode(left.left, left.value, t)
+		}
+
+		return protateLeft(newPNode(left.left, left.value, protateRight(t)))
+	}
+
+	t := pjoinRight(left.right, v, right)
+	out := newPNode(left.left, left.value, t)
+	if t.height <= left.left.depth()+1 {
+		return out
+	}
+
+	return protateLeft(out)
+}
+
+// pjoinLeft joins a shorter left tree onto the left spine of right
+func pjoinLeft[T any](left *pnode[T], v T, right *pnode[T]) *pnode[T] {
+	if right.left.depth() <= left.depth()+1 {
+		t := newPNode(left, v, right.left)
+		if t.height <= right.right.depth()+1 {
+			return newPNode(t, right.value, right.right)
+		}
+
+		return protateRight(newPNode(protateLeft(t), right.value, right.right))
+	}
+
+	t := pjoinLeft(left, v, right.left)
+	out := newPNode(t, right.value, right.right)
+	if t.height <= right.right.depth()+1 {
+		return out
+	}
+
+	return protateRight(out)
+}
+
+// pjoin2 returns a balanced tree of the elements of left then right
+func pjoin2[T any](left, right *pnode[T]) *pnode[T] {
+	if left == nil {
+		return right
+	}
+
+	if right == nil {
+		return left
+	}
+
+	rest, last := psplitLast(left)
+	return pjoin(rest, last, right)
+}
+
+// psplitLast returns the tree without its last element, and that element
+func psplitLast[T any](n *pnode[T]) (*pnode[T], T) {
+	if n.right == nil {
+		return n.left, n.value
+	}
+
+	rest, last := psplitLast(n.right)
+	return pjoin(n.left, n.value, rest), last
+}
+
+// psplit returns a tree of the first i elements and a tree of the rest
+func psplit[T any](n *pnode[T], i int) (*pnode[T], *pnode[T]) {
+	if n == nil {
+		return nil, nil
+	}
+
+	l := n.left.len()
+	if i <= l {
+		a, b := psplit(n.left, i)
+		return a, pjoin(b, n.value, n.right)
+	}
+
+	a, b := psplit(n.right, i-l-1)
+	return pjoin(n.left, n.value, a), b
+}
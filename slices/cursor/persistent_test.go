@@ -0,0 +1,235 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// checkTree verifies the sizes and balance of every node of the tree
+func checkTree[T any](t *testing.T, n *pnode[T]) {
+	t.Helper()
+
+	if n == nil {
+		return
+	}
+
+	if n.size != n.left.len()+n.right.len()+1 {
+		t.Fatalf("expected size %v, got %v", n.left.len()+n.right.len()+1, n.size)
+	}
+
+	if d := n.left.depth() - n.right.depth(); d < -1 || d > 1 {
+		t.Fatalf("expected a balanced node, got a difference of %v", d)
+	}
+
+	checkTree(t, n.left)
+	checkTree(t, n.right)
+}
+
+func Test_Persistent_Versions(t *testing.T) {
+	v0 := NewPersistent([]int{1, 2, 3, 4, 5})
+	_, _ = v0.Seek(2)
+
+	v1, err := v0.Set(30)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	v2, _ := v1.Insert(10, 20)
+	v3, _ := v2.DeleteAt(0)
+	v4 := v3.Prepend(-1).Append(6)
+	v5, _ := v4.Chop(1, 3)
+	v6, _ := v5.ReplaceAt(0, 7, 8)
+
+	tests := []struct {
+		version *Persistent[int]
+		want    []int
+		pos     int
+	}{
+		{v0, []int{1, 2, 3, 4, 5}, 2},
+		{v1, []int{1, 2, 30, 4, 5}, 2},
+		{v2, []int{1, 2, 10, 20, 30, 4, 5}, 2},
+		{v3, []int{2, 10, 20, 30, 4, 5}, 2},
+		{v4, []int{-1, 2, 10, 20, 30, 4, 5, 6}, 3},
+		{v5, []int{-1, 20, 30, 4, 5, 6}, 3},
+		{v6, []int{7, 8, 30, 4, 5, 6}, 3},
+	}
+
+	for i, tt := range tests {
+		diff := cmp.Diff(tt.version.Values(), tt.want)
+		if diff != "" {
+			t.Fatalf("version %v: %s", i, diff)
+		}
+
+		if tt.version.Pos() != tt.pos {
+			t.Fatalf("version %v: expected %v, got %v", i, tt.pos, tt.version.Pos())
+		}
+	}
+
+	// Moving one version does not move another
+	_, _ = v1.Seek(0)
+	if v, _ := v0.Get(); v != 3 {
+		t.Fatalf("expected %v, got %v", 3, v)
+	}
+}
+
+func Test_Persistent_Errors(t *testing.T) {
+	p := NewPersistent([]int{1, 2, 3})
+
+	if _, err := p.Seek(3); err != ErrIndexOutOfRange {
+		t.Fatalf("expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+
+	if _, err := p.InsertAt(-1, 1); err != ErrIndexOutOfRange {
+		t.Fatalf("expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+
+	if _, err := p.ReplaceAt(2, 1, 2); err != ErrOverflow {
+		t.Fatalf("expected %v, got %v", ErrOverflow, err)
+	}
+
+	if _, _, err := p.Take(4); err != ErrUnderflow {
+		t.Fatalf("expected %v, got %v", ErrUnderflow, err)
+	}
+
+	if _, err := NewPersistent[int](nil).Set(1); err != ErrIndexOutOfRange {
+		t.Fatalf("expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+}
+
+func Test_Persistent_Take(t *testing.T) {
+	p := NewPersistent([]int{1, 2, 3, 4, 5})
+	_, _ = p.Seek(1)
+
+	taken, rem, err := p.Take(2)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(taken, []int{2, 3})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	diff = cmp.Diff(rem.Values(), []int{4, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	skipped, err := p.Skip(3)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff = cmp.Diff(skipped.Values(), []int{5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	var all []int
+	for i, v := range p.All() {
+		if i != len(all) {
+			t.Fatalf("expected %v, got %v", len(all), i)
+		}
+
+		all = append(all, v)
+	}
+
+	diff = cmp.Diff(all, []int{1, 2, 3, 4, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+// Test_Persistent_Random applies random edits to a persistent cursor and a
+// slice, keeping every version, and checks the versions never change
+func Test_Persistent_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	type version struct {
+		p    *Persistent[int]
+		want []int
+	}
+
+	versions := []version{{NewPersistent([]int{0}), []int{0}}}
+
+	for i := 1; i < 2000; i++ {
+		base := versions[rng.Intn(len(versions))]
+		p, want := base.p, slices.Clone(base.want)
+
+		pos := rng.Intn(len(want))
+		_, _ = p.Seek(pos)
+
+		switch rng.Intn(4) {
+		case 0:
+			values := []int{i, -i}
+			p, _ = p.Insert(values...)
+			want = slices.Insert(want, pos, values...)
+		case 1:
+			if len(want) > 1 {
+				p, _ = p.Delete()
+				want = slices.Delete(want, pos, pos+1)
+			}
+		case 2:
+			p, _ = p.Set(i)
+			want[pos] = i
+		default:
+			values := make([]int, rng.Intn(50))
+			for j := range values {
+				values[j] = i * j
+			}
+
+			p = p.Append(values...)
+			want = append(want, values...)
+		}
+
+		versions = append(versions, version{p, want})
+	}
+
+	for i, v := range versions {
+		diff := cmp.Diff(v.p.Values(), v.want)
+		if diff != "" {
+			t.Fatalf("version %v: %s", i, diff)
+		}
+
+		for j := range v.want {
+			if got, _ := v.p.Seek(j); got != v.want[j] {
+				t.Fatalf("version %v: expected %v at %v, got %v", i, v.want[j], j, got)
+			}
+		}
+
+		checkTree(t, v.p.root)
+	}
+}
+
+func Test_Persistent_Height(t *testing.T) {
+	p := NewPersistent[int](nil)
+	for i := 0; i < 10_000; i++ {
+		p = p.Append(i)
+	}
+
+	// An AVL tree is at most about 1.44 times the optimal height
+	limit := int(1.45*math.Log2(float64(p.Len()+2))) + 1
+	if p.root.depth() > limit {
+		t.Fatalf("expected a height of at most %v, got %v", limit, p.root.depth())
+	}
+
+	checkTree(t, p.root)
+}
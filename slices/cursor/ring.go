@@ -0,0 +1,152 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+// minRing is the smallest capacity allocated when a ring buffer grows
+const minRing = 16
+
+// ringStorage holds the elements in a circular buffer. Edits shift
+// whichever side of the edit is shorter, so inserts and deletes at either
+// end of the buffer are amortized O(1).
+type ringStorage[T any] struct {
+	data []T
+	head int
+	size int
+}
+
+// RingStorage returns storage holding the elements in a circular buffer,
+// which suits queue-like workloads that add and remove elements at both
+// ends of the cursor.
+func RingStorage[T any](data []T) Storage[T] {
+	return &ringStorage[T]{data: data, size: len(data)}
+}
+
+// index maps a logical index to its index in data
+func (r *ringStorage[T]) index(i int) int {
+	i += r.head
+	if i >= len(r.data) {
+		i -= len(r.data)
+	}
+
+	return i
+}
+
+func (r *ringStorage[T]) Len() int {
+	return r.size
+}
+
+func (r *ringStorage[T]) At(i int) T {
+	return r.data[r.index(i)]
+}
+
+func (r *ringStorage[T]) Set(i int, v T) {
+	r.data[r.index(i)] = v
+}
+
+func (r *ringStorage[T]) Slice(start, end int) []T {
+	out := make([]T, end-start)
+	if len(out) == 0 {
+		return out
+	}
+
+	// Copy up to the end of data and then any part which wrapped around
+	first := r.index(start)
+	n := copy(out, r.data[first:min(len(r.data), first+len(out))])
+	copy(out[n:], r.data)
+
+	return out
+}
+
+func (r *ringStorage[T]) Splice(start, end int, values []T) {
+	removed := end - start
+	delta := len(values) - removed
+
+	switch {
+	case delta > 0:
+		r.grow(r.size + delta)
+
+		if start < r.size-end {
+			// Move the elements before the edit towards the front
+			r.head = r.index(len(r.data) - delta)
+			for i := 0; i < start; i++ {
+				r.Set(i, r.At(i+delta))
+			}
+		} else {
+			// Move the elements after the edit towards the back
+			for i := r.size - 1; i >= end; i-- {
+				r.data[r.index(i+delta)] = r.At(i)
+			}
+		}
+	case delta < 0:
+		shrink := -delta
+
+		if start < r.size-end {
+			// Move the elements before the edit towards the back
+			for i := start - 1; i >= 0; i-- {
+				r.Set(i+shrink, r.At(i))
+			}
+
+			for i := 0; i < shrink; i++ {
+				r.clear(i)
+			}
+
+			r.head = r.index(shrink)
+		} else {
+			// Move the elements after the edit towards the front
+			for i := end; i < r.size; i++ {
+				r.Set(i-shrink, r.At(i))
+			}
+
+			for i := r.size - shrink; i < r.size; i++ {
+				r.clear(i)
+			}
+		}
+	}
+
+	r.size += delta
+
+	for i, v := range values {
+		r.Set(start+i, v)
+	}
+
+	// Give back the memory of a buffer which has mostly been emptied
+	if len(r.data) > minRing && r.size < len(r.data)/4 {
+		r.resize(max(2*r.size, minRing))
+	}
+}
+
+func (r *ringStorage[T]) clear(i int) {
+	var zero T
+	r.Set(i, zero)
+}
+
+// grow ensures the buffer can hold at least n elements, reallocating it
+// when it is too small
+func (r *ringStorage[T]) grow(n int) {
+	if n <= len(r.data) {
+		return
+	}
+
+	r.resize(max(n, 2*len(r.data), minRing))
+}
+
+// resize moves the elements to the start of a new buffer of length n
+func (r *ringStorage[T]) resize(n int) {
+	data := make([]T, n)
+	copy(data, r.Slice(0, r.size))
+
+	r.data = data
+	r.head = 0
+}
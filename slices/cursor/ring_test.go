@@ -0,0 +1,28 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"testing"
+)
+
+func Test_RingStorage_Shrink(t *testing.T) {
+	s := RingStorage(make([]int, 1000)).(*ringStorage[int])
+	s.Splice(0, 990, nil)
+
+	if s.Len() != 10 || len(s.data) > 20 {
+		t.Fatalf("expected %v elements in at most %v, got %v in %v", 10, 20, s.Len(), len(s.data))
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"errors"
+	"iter"
+	"slices"
+)
+
+// ErrNotFound is returned when a search finds no match.
+var ErrNotFound = errors.New("not found")
+
+// Match is a match of a pattern within a cursor, from Start to End
+// exclusive. Pattern is the index of the pattern which matched, which is
+// always zero for the single pattern searches.
+type Match struct {
+	Pattern int
+	Start   int
+	End     int
+}
+
+// Len returns the number of elements matched
+func (m Match) Len() int {
+	return m.End - m.Start
+}
+
+// Find searches for the first match of the pattern starting at or after
+// the position of the cursor and seeks the cursor to the start of it. If
+// there is no match it returns ErrNotFound and the cursor is left where it
+// was. An empty pattern never matches.
+//
+// The search uses the Knuth-Morris-Pratt algorithm so it takes
+// O(n + len(pattern)) time whatever the input.
+func Find[T comparable](c *Cursor[T], pattern ...T) (Match, error) {
+	return find(c, c.pos, pattern)
+}
+
+// FindNext is like Find but only matches which start after the position
+// of the cursor, so repeated calls step through every match.
+func FindNext[T comparable](c *Cursor[T], pattern ...T) (Match, error) {
+	return find(c, c.pos+1, pattern)
+}
+
+// FindPrev searches backwards for the last match of the pattern which
+// starts before the position of the cursor and seeks the cursor to the
+// start of it.
+func FindPrev[T comparable](c *Cursor[T], pattern ...T) (Match, error) {
+	n := c.store().Len()
+	if len(pattern) == 0 || c.pos <= 0 {
+		return Match{}, ErrNotFound
+	}
+
+	// Search the buffer in reverse from the end of the last match which
+	// could start before the cursor, so the first match found is the last
+	// match in the buffer
+	reversed := slices.Clone(pattern)
+	slices.Reverse(reversed)
+
+	from := max(0, n-min(c.pos, n)-len(pattern)+1)
+	i := kmp(reversed, prefixes(reversed), n, from, func(i int) T {
+		return c.store().At(n - 1 - i)
+	})
+	if i < 0 {
+		return Match{}, ErrNotFound
+	}
+
+	m := Match{Start: n - i - len(pattern), End: n - i}
+	c.move(m.Start)
+
+	return m, nil
+}
+
+// FindAll returns an iterator over the matches of the pattern from the
+// position of the cursor to the end of the buffer, seeking the cursor to
+// each match as it is yielded. Matches do not overlap.
+func FindAll[T comparable](c *Cursor[T], pattern ...T) iter.Seq[Match] {
+	return func(yield func(Match) bool) {
+		if len(pattern) == 0 {
+			return
+		}
+
+		table := prefixes(pattern)
+		for from := c.pos; ; {
+			i := kmp(pattern, table, c.store().Len(), from, c.store().At)
+			if i < 0 {
+				return
+			}
+
+			c.move(i)
+			if !yield(Match{Start: i, End: i + len(pattern)}) {
+				return
+			}
+
+			from = i + len(pattern)
+		}
+	}
+}
+
+// find searches for the pattern from the given index and seeks the cursor
+// to the match
+func find[T comparable](c *Cursor[T], from int, pattern []T) (Match, error) {
+	if len(pattern) == 0 || from < 0 {
+		return Match{}, ErrNotFound
+	}
+
+	i := kmp(pattern, prefixes(pattern), c.store().Len(), from, c.store().At)
+	if i < 0 {
+		return Match{}, ErrNotFound
+	}
+
+	c.move(i)
+	return Match{Start: i, End: i + len(pattern)}, nil
+}
+
+// prefixes returns the KMP failure table of the pattern, holding for each
+// prefix the length of the longest proper prefix which is also a suffix
+func prefixes[T comparable](pattern []T) []int {
+	table := make([]int, len(pattern))
+
+	k := 0
+	for i := 1; i < len(pattern); i++ {
+		for k > 0 && pattern[i] != pattern[k] {
+			k = table[k-1]
+		}
+
+		if pattern[i] == pattern[k] {
+			k++
+		}
+
+		table[i] = k
+	}
+
+	return table
+}
+
+// kmp returns the index of the first match of the pattern in the n
+// elements returned by at, starting the search from index from, or -1 if
+// there is no match
+func kmp[T comparable](pattern []T, table []int, n, from int, at func(int) T) int {
+	k := 0
+	for i := from; i < n; i++ {
+		v := at(i)
+		for k > 0 && v != pattern[k] {
+			k = table[k-1]
+		}
+
+		if v == pattern[k] {
+			k++
+		}
+
+		if k == len(pattern) {
+			return i - k + 1
+		}
+	}
+
+	return -1
+}
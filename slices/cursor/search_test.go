@@ -0,0 +1,204 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Find(t *testing.T) {
+	tests := []struct {
+		input   string
+		pos     int
+		pattern string
+		find    func(c *Cursor[byte], pattern ...byte) (Match, error)
+		want    Match
+		err     error
+	}{
+		{"abcabcabd", 0, "abd", Find[byte], Match{Start: 6, End: 9}, nil},
+		{"abcabcabd", 0, "abc", Find[byte], Match{Start: 0, End: 3}, nil},
+		{"abcabcabd", 1, "abc", Find[byte], Match{Start: 3, End: 6}, nil},
+		{"abcabcabd", 0, "abc", FindNext[byte], Match{Start: 3, End: 6}, nil},
+		{"abcabcabd", 3, "abc", FindNext[byte], Match{}, ErrNotFound},
+		{"aaaa", 0, "aa", FindNext[byte], Match{Start: 1, End: 3}, nil},
+		{"abcabcabd", 8, "abc", FindPrev[byte], Match{Start: 3, End: 6}, nil},
+		{"abcabcabd", 3, "abc", FindPrev[byte], Match{Start: 0, End: 3}, nil},
+		{"abcabcabd", 4, "abc", FindPrev[byte], Match{Start: 3, End: 6}, nil},
+		{"abcabcabd", 0, "abc", FindPrev[byte], Match{}, ErrNotFound},
+		{"abcabcabd", 0, "xyz", Find[byte], Match{}, ErrNotFound},
+		{"abc", 0, "", Find[byte], Match{}, ErrNotFound},
+		{"abc", 0, "abcd", Find[byte], Match{}, ErrNotFound},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			c := New([]byte(tt.input))
+			c.pos = tt.pos
+
+			got, err := tt.find(c, []byte(tt.pattern)...)
+			if err != tt.err {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+
+			want := tt.want.Start
+			if err != nil {
+				want = tt.pos
+			}
+
+			if c.Pos() != want {
+				t.Fatalf("expected %v, got %v", want, c.Pos())
+			}
+		})
+	}
+}
+
+func Test_FindAll(t *testing.T) {
+	c := New([]byte("aaaaa"))
+
+	var got []Match
+	for m := range FindAll(c, 'a', 'a') {
+		if c.Pos() != m.Start {
+			t.Fatalf("expected %v, got %v", m.Start, c.Pos())
+		}
+
+		got = append(got, m)
+	}
+
+	diff := cmp.Diff(got, []Match{{Start: 0, End: 2}, {Start: 2, End: 4}})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+// Test_Find_Random checks the searches against a brute force search over
+// random inputs drawn from a small alphabet so that matches are common
+func Test_Find_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	gen := func(n int) []byte {
+		out := make([]byte, n)
+		for i := range out {
+			out[i] = "ab"[rng.Intn(2)]
+		}
+
+		return out
+	}
+
+	for i := 0; i < 500; i++ {
+		input := gen(rng.Intn(40))
+		pattern := gen(1 + rng.Intn(4))
+		pos := rng.Intn(len(input) + 1)
+
+		next := -1
+		prev := -1
+		for j := 0; j+len(pattern) <= len(input); j++ {
+			if !slices.Equal(input[j:j+len(pattern)], pattern) {
+				continue
+			}
+
+			if j >= pos && next < 0 {
+				next = j
+			}
+
+			if j < pos {
+				prev = j
+			}
+		}
+
+		c := New(input)
+		c.pos = pos
+
+		m, err := Find(c, pattern...)
+		if (err == nil && m.Start != next) || (err != nil && next >= 0) {
+			t.Fatalf("%s in %s from %v: expected %v, got %v (%v)", pattern, input, pos, next, m.Start, err)
+		}
+
+		c.pos = pos
+		m, err = FindPrev(c, pattern...)
+		if (err == nil && m.Start != prev) || (err != nil && prev >= 0) {
+			t.Fatalf("%s in %s before %v: expected %v, got %v (%v)", pattern, input, pos, prev, m.Start, err)
+		}
+	}
+}
+
+func Test_Matcher(t *testing.T) {
+	m := NewMatcher([]byte("he"), []byte("she"), []byte("his"), []byte("hers"), nil)
+
+	tests := []struct {
+		pos  int
+		find func(c *Cursor[byte]) (Match, error)
+		want Match
+		err  error
+	}{
+		{0, m.Find, Match{Pattern: 1, Start: 1, End: 4}, nil},
+		{2, m.Find, Match{Pattern: 3, Start: 2, End: 6}, nil},
+		{1, m.FindNext, Match{Pattern: 3, Start: 2, End: 6}, nil},
+		{7, m.Find, Match{Pattern: 2, Start: 7, End: 10}, nil},
+		{8, m.Find, Match{}, ErrNotFound},
+		{10, m.FindPrev, Match{Pattern: 2, Start: 7, End: 10}, nil},
+		{7, m.FindPrev, Match{Pattern: 3, Start: 2, End: 6}, nil},
+		{2, m.FindPrev, Match{Pattern: 1, Start: 1, End: 4}, nil},
+		{1, m.FindPrev, Match{}, ErrNotFound},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			c := New([]byte("ushers his"))
+			c.pos = tt.pos
+
+			got, err := tt.find(c)
+			if err != tt.err {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_Matcher_FindAll(t *testing.T) {
+	words := []string{"cat", "category", "dog", "go"}
+
+	patterns := make([][]rune, len(words))
+	for i, w := range words {
+		patterns[i] = []rune(w)
+	}
+
+	c := New([]rune("a category of dogs, cats and gophers"))
+
+	var got []string
+	for match := range NewMatcher(patterns...).FindAll(c) {
+		s, _ := c.Slice(match.Start, match.End)
+		got = append(got, string(s))
+	}
+
+	want := strings.Fields("category dog cat go")
+	diff := cmp.Diff(got, want)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Serialization covers the state of a cursor which can be represented as
+// data: the elements of the buffer, the position and the capacity. The
+// options which hold functions or references are not encoded:
+//
+//   - LessFn, Compare and the storage backend are kept from the cursor
+//     being decoded into. A zero value Cursor decodes with slice storage
+//     and the default ordering of New, so a cursor which relied on LessFn
+//     must be created with it again before decoding.
+//   - Marks, observers and the history are not encoded either. Those of
+//     the cursor being decoded into see the decoded contents as a single
+//     replacement of the whole buffer, so the decode can be undone when
+//     History is enabled.
+//
+// Elements are encoded with encoding/json or encoding/gob, so their type
+// must be supported by the encoding in use, including registering the
+// concrete types of interface elements with gob.Register.
+
+// snapshot is the encoded form of a cursor
+type snapshot[T any] struct {
+	Values []T `json:"values"`
+	Pos    int `json:"pos"`
+	Cap    int `json:"cap"`
+}
+
+// snapshot returns the encoded form of the cursor
+func (c *Cursor[T]) snapshot() snapshot[T] {
+	s := c.store()
+
+	return snapshot[T]{
+		Values: s.Slice(0, s.Len()),
+		Pos:    c.pos,
+		Cap:    c.cap,
+	}
+}
+
+// restore replaces the state of the cursor with the snapshot
+func (c *Cursor[T]) restore(s snapshot[T]) error {
+	if s.Pos < 0 || s.Pos > len(s.Values) {
+		return ErrIndexOutOfRange
+	}
+
+	c.store()
+	c.cap = max(s.Cap, 0)
+
+	c.splice(0, c.store().Len(), s.Values)
+	c.move(s.Pos)
+
+	return nil
+}
+
+// MarshalJSON encodes the elements, position and capacity of the cursor
+// as a JSON object
+func (c *Cursor[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.snapshot())
+}
+
+// UnmarshalJSON replaces the elements, position and capacity of the
+// cursor with those encoded by MarshalJSON. ErrIndexOutOfRange is
+// returned, leaving the cursor unchanged, if the position is outside the
+// decoded elements.
+func (c *Cursor[T]) UnmarshalJSON(data []byte) error {
+	var s snapshot[T]
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+
+	return c.restore(s)
+}
+
+// GobEncode encodes the elements, position and capacity of the cursor
+// with encoding/gob
+func (c *Cursor[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(c.snapshot())
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GobDecode replaces the elements, position and capacity of the cursor
+// with those encoded by GobEncode, in the same way as UnmarshalJSON
+func (c *Cursor[T]) GobDecode(data []byte) error {
+	var s snapshot[T]
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&s)
+	if err != nil {
+		return err
+	}
+
+	return c.restore(s)
+}
+
+// MarshalBinary encodes the cursor in the same format as GobEncode
+func (c *Cursor[T]) MarshalBinary() ([]byte, error) {
+	return c.GobEncode()
+}
+
+// UnmarshalBinary decodes a cursor encoded by MarshalBinary
+func (c *Cursor[T]) UnmarshalBinary(data []byte) error {
+	return c.GobDecode(data)
+}
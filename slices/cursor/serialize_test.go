@@ -0,0 +1,177 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Cursor_Serialize(t *testing.T) {
+	codecs := map[string]struct {
+		encode func(*Cursor[string]) ([]byte, error)
+		decode func(*Cursor[string], []byte) error
+	}{
+		"json": {
+			encode: func(c *Cursor[string]) ([]byte, error) { return json.Marshal(c) },
+			decode: func(c *Cursor[string], data []byte) error { return json.Unmarshal(data, c) },
+		},
+		"gob": {
+			encode: func(c *Cursor[string]) ([]byte, error) {
+				var buf bytes.Buffer
+				err := gob.NewEncoder(&buf).Encode(c)
+				return buf.Bytes(), err
+			},
+			decode: func(c *Cursor[string], data []byte) error {
+				return gob.NewDecoder(bytes.NewReader(data)).Decode(c)
+			},
+		},
+		"binary": {
+			encode: (*Cursor[string]).MarshalBinary,
+			decode: (*Cursor[string]).UnmarshalBinary,
+		},
+	}
+
+	tests := []struct {
+		data []string
+		pos  int
+		opts []Option[string]
+	}{
+		{[]string{"a", "b", "c"}, 1, nil},
+		{[]string{"a", "b", "c"}, 2, []Option[string]{Cap[string](5)}},
+		{[]string{"a"}, 0, []Option[string]{Cap[string](0)}},
+		{nil, 0, nil},
+	}
+
+	for name, codec := range codecs {
+		for i, tt := range tests {
+			t.Run(fmt.Sprintf("%s/test_%v", name, i), func(t *testing.T) {
+				c := New(tt.data, tt.opts...)
+				c.pos = tt.pos
+
+				data, err := codec.encode(c)
+				if err != nil {
+					t.Fatalf("expected %v, got %v", nil, err)
+				}
+
+				var got Cursor[string]
+				err = codec.decode(&got, data)
+				if err != nil {
+					t.Fatalf("expected %v, got %v", nil, err)
+				}
+
+				diff := cmp.Diff(items(&got), items(c))
+				if diff != "" {
+					t.Fatalf(diff)
+				}
+
+				if got.Pos() != c.Pos() {
+					t.Fatalf("expected %v, got %v", c.Pos(), got.Pos())
+				}
+
+				if got.Cap() != c.Cap() {
+					t.Fatalf("expected %v, got %v", c.Cap(), got.Cap())
+				}
+
+				// The decoded cursor must be fully usable
+				err = got.Append("z")
+				if c.Available() > 0 && err != nil {
+					t.Fatalf("expected %v, got %v", nil, err)
+				}
+			})
+		}
+	}
+}
+
+func Test_Cursor_UnmarshalJSON_Format(t *testing.T) {
+	data, err := json.Marshal(New([]int{1, 2}, Cap[int](2)))
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if string(data) != `{"values":[1,2],"pos":0,"cap":2}` {
+		t.Fatalf("expected %s, got %s", `{"values":[1,2],"pos":0,"cap":2}`, data)
+	}
+
+	c := New([]int{9})
+	err = json.Unmarshal([]byte(`{"values":[1,2],"pos":3,"cap":5}`), c)
+	if err != ErrIndexOutOfRange {
+		t.Fatalf("expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+
+	diff := cmp.Diff(items(c), []int{9})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Cursor_Unmarshal_KeepsOptions(t *testing.T) {
+	var events []Event
+	c := New([]int{1, 2, 3},
+		WithStorage(GapStorage[int]),
+		History[int](0),
+		Observe[int](func(e Event) { events = append(events, e) }),
+		Compare(func(a, b int) int { return b - a }),
+	)
+
+	m, _ := c.Mark(2)
+
+	err := json.Unmarshal([]byte(`{"values":[4,6,5],"pos":1,"cap":10}`), c)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	// The comparator sorts in descending order
+	err = c.Sort()
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(items(c), []int{6, 5, 4})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if _, ok := c.buff.(*gapStorage[int]); !ok {
+		t.Fatalf("expected gap storage, got %T", c.buff)
+	}
+
+	if m.Pos() > c.Len() {
+		t.Fatalf("expected the mark within the buffer, got %v", m.Pos())
+	}
+
+	want := []Event{
+		Replaced[int]{Start: 0, End: 3, Values: []int{4, 6, 5}},
+		Moved{From: 0, To: 1},
+	}
+
+	diff = cmp.Diff(events[:2], want)
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	_ = c.Undo()
+	_ = c.Undo()
+
+	diff = cmp.Diff(items(c), []int{1, 2, 3})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
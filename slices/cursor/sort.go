@@ -0,0 +1,222 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"sort"
+)
+
+// ErrNoOrder is returned when an operation needs to order the elements of
+// a cursor which has no way to compare them.
+var ErrNoOrder = errors.New("no ordering for elements")
+
+// Compare orders the elements of the cursor with fn, which returns a
+// negative number when a sorts before b, a positive number when it sorts
+// after b and zero when they are equal.
+//
+// Cursors over the built-in ordered types, such as int and string, use
+// cmp.Compare by default. Otherwise, without Compare or LessFn, the cursor
+// uses the Less(T) bool method of the elements if they have one.
+func Compare[T any](fn func(a, b T) int) Option[T] {
+	return func(c *Cursor[T]) {
+		c.cmpFn = fn
+	}
+}
+
+// Ordered orders the elements of the cursor with cmp.Compare. It is for
+// types whose underlying type is ordered, such as a named int, which are
+// not picked up by the default comparator.
+func Ordered[T cmp.Ordered]() Option[T] {
+	return Compare(cmp.Compare[T])
+}
+
+// lesser is implemented by elements which know how to order themselves
+type lesser[T any] interface {
+	Less(other T) bool
+}
+
+// comparator returns the function used to compare elements by value, or
+// nil if the elements cannot be compared
+func (c *Cursor[T]) comparator() func(a, b T) int {
+	c.store()
+	if c.cmpFn != nil {
+		return c.cmpFn
+	}
+
+	var zero T
+	if _, ok := any(zero).(lesser[T]); !ok {
+		return nil
+	}
+
+	return func(a, b T) int {
+		switch {
+		case any(a).(lesser[T]).Less(b):
+			return -1
+		case any(b).(lesser[T]).Less(a):
+			return 1
+		default:
+			return 0
+		}
+	}
+}
+
+// Sort sorts the elements of the cursor. The sort is applied as a single
+// edit, so it can be undone in one step, and the cursor stays at the same
+// index. It returns ErrNoOrder if the elements cannot be compared.
+func (c *Cursor[T]) Sort() error {
+	return c.sort(false)
+}
+
+// SortStable is like Sort but keeps equal elements in their original order
+func (c *Cursor[T]) SortStable() error {
+	return c.sort(true)
+}
+
+func (c *Cursor[T]) sort(stable bool) error {
+	values := c.store().Slice(0, c.store().Len())
+
+	switch compare := c.comparator(); {
+	case c.lessFn != nil && c.cmpFn == nil:
+		// LessFn compares by index, so sort the indexes against the
+		// buffer as it is and then gather the elements
+		idx := make([]int, len(values))
+		for i := range idx {
+			idx[i] = i
+		}
+
+		less := func(a, b int) bool { return c.lessFn(idx[a], idx[b]) }
+		if stable {
+			sort.SliceStable(idx, less)
+		} else {
+			sort.Slice(idx, less)
+		}
+
+		sorted := make([]T, len(values))
+		for i, j := range idx {
+			sorted[i] = values[j]
+		}
+
+		values = sorted
+	case compare == nil:
+		return ErrNoOrder
+	case stable:
+		slices.SortStableFunc(values, compare)
+	default:
+		slices.SortFunc(values, compare)
+	}
+
+	c.splice(0, len(values), values)
+	return nil
+}
+
+// IsSorted reports whether the elements of the cursor are sorted according
+// to Less
+func (c *Cursor[T]) IsSorted() bool {
+	for i := c.store().Len() - 1; i > 0; i-- {
+		if c.Less(i, i-1) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// BinarySearch searches the sorted cursor for v and returns the index of
+// the first element equal to it and true, or the index where v would be
+// inserted and false. It needs the comparator of the cursor, as LessFn
+// cannot compare against values outside the buffer, and reports false
+// without one.
+func (c *Cursor[T]) BinarySearch(v T) (int, bool) {
+	compare := c.comparator()
+	if compare == nil {
+		return 0, false
+	}
+
+	n := c.store().Len()
+	i := sort.Search(n, func(i int) bool {
+		return compare(c.store().At(i), v) >= 0
+	})
+
+	return i, i < n && compare(c.store().At(i), v) == 0
+}
+
+// InsertSorted inserts each value into the sorted cursor at the index
+// which keeps it sorted. Values are inserted after any equal elements, so
+// elements which compare equal keep the order in which they were
+// inserted. It returns ErrNoOrder if the elements cannot be compared.
+func (c *Cursor[T]) InsertSorted(values ...T) error {
+	compare := c.comparator()
+	if compare == nil {
+		return ErrNoOrder
+	}
+
+	if !c.fits(len(values)) {
+		return ErrOverflow
+	}
+
+	for _, v := range values {
+		i := sort.Search(c.store().Len(), func(i int) bool {
+			return compare(c.store().At(i), v) > 0
+		})
+
+		c.splice(i, i, []T{v})
+	}
+
+	return nil
+}
+
+// orderedCompare returns cmp.Compare for the built-in ordered types and
+// nil for any other type
+func orderedCompare[T any]() func(a, b T) int {
+	var fn any
+
+	var zero T
+	switch any(zero).(type) {
+	case int:
+		fn = cmp.Compare[int]
+	case int8:
+		fn = cmp.Compare[int8]
+	case int16:
+		fn = cmp.Compare[int16]
+	case int32:
+		fn = cmp.Compare[int32]
+	case int64:
+		fn = cmp.Compare[int64]
+	case uint:
+		fn = cmp.Compare[uint]
+	case uint8:
+		fn = cmp.Compare[uint8]
+	case uint16:
+		fn = cmp.Compare[uint16]
+	case uint32:
+		fn = cmp.Compare[uint32]
+	case uint64:
+		fn = cmp.Compare[uint64]
+	case uintptr:
+		fn = cmp.Compare[uintptr]
+	case float32:
+		fn = cmp.Compare[float32]
+	case float64:
+		fn = cmp.Compare[float64]
+	case string:
+		fn = cmp.Compare[string]
+	}
+
+	compare, _ := fn.(func(a, b T) int)
+	return compare
+}
@@ -0,0 +1,220 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type version struct {
+	major, minor int
+}
+
+func (v version) Less(o version) bool {
+	return v.major < o.major || (v.major == o.major && v.minor < o.minor)
+}
+
+type level int
+
+func Test_Cursor_Sort(t *testing.T) {
+	tests := []struct {
+		name string
+		sort func() (any, error)
+		want any
+		err  error
+	}{
+		{"default ints", func() (any, error) {
+			c := New([]int{3, 1, 2})
+			err := c.Sort()
+			return items(c), err
+		}, []int{1, 2, 3}, nil},
+		{"default strings", func() (any, error) {
+			c := New([]string{"b", "c", "a"})
+			err := c.Sort()
+			return items(c), err
+		}, []string{"a", "b", "c"}, nil},
+		{"compare", func() (any, error) {
+			c := New([]int{3, 1, 2}, Compare(func(a, b int) int { return b - a }))
+			err := c.Sort()
+			return items(c), err
+		}, []int{3, 2, 1}, nil},
+		{"ordered named type", func() (any, error) {
+			c := New([]level{3, 1, 2}, Ordered[level]())
+			err := c.Sort()
+			return items(c), err
+		}, []level{1, 2, 3}, nil},
+		{"less method", func() (any, error) {
+			c := New([]version{{1, 2}, {0, 9}, {1, 0}})
+			err := c.Sort()
+			return items(c), err
+		}, []version{{0, 9}, {1, 0}, {1, 2}}, nil},
+		{"no order", func() (any, error) {
+			c := New([]level{3, 1, 2})
+			err := c.Sort()
+			return items(c), err
+		}, []level{3, 1, 2}, ErrNoOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.sort()
+			if err != tt.err {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(version{}))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func Test_Cursor_Sort_LessFn(t *testing.T) {
+	var c *Cursor[string]
+	c = New([]string{"bb", "a", "cc", "b", "aa"}, LessFn[string](func(i, j int) bool {
+		return len(c.buff.At(i)) < len(c.buff.At(j))
+	}))
+
+	if c.IsSorted() {
+		t.Fatal("expected the cursor not to be sorted")
+	}
+
+	if err := c.SortStable(); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(items(c), []string{"a", "b", "bb", "cc", "aa"})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if !c.IsSorted() {
+		t.Fatal("expected the cursor to be sorted")
+	}
+
+	// LessFn takes precedence over the default comparator
+	if c.Less(0, 2) != true || c.Less(2, 3) != false {
+		t.Fatal("expected Less to use LessFn")
+	}
+}
+
+func Test_Cursor_Sort_Undo(t *testing.T) {
+	c := New([]int{3, 1, 2}, History[int](0))
+	_, _ = c.Seek(1)
+
+	if err := c.Sort(); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if c.Pos() != 1 {
+		t.Fatalf("expected %v, got %v", 1, c.Pos())
+	}
+
+	if err := c.Undo(); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(items(c), []int{3, 1, 2})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Cursor_Less(t *testing.T) {
+	c := New([]int{2, 1})
+	if !sort.IsSorted(sort.Reverse(c)) {
+		t.Fatal("expected the cursor to satisfy sort.Interface")
+	}
+
+	if c.Less(0, 5) || c.Less(-1, 0) {
+		t.Fatal("expected out of range indexes to compare false")
+	}
+
+	sort.Sort(c)
+	diff := cmp.Diff(items(c), []int{1, 2})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Cursor_BinarySearch(t *testing.T) {
+	c := New([]int{1, 3, 3, 5, 7})
+
+	tests := []struct {
+		v     int
+		index int
+		found bool
+	}{
+		{0, 0, false},
+		{1, 0, true},
+		{3, 1, true},
+		{4, 3, false},
+		{7, 4, true},
+		{8, 5, false},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			index, found := c.BinarySearch(tt.v)
+			if index != tt.index || found != tt.found {
+				t.Fatalf("expected %v %v, got %v %v", tt.index, tt.found, index, found)
+			}
+		})
+	}
+
+	if _, found := New([]level{1}).BinarySearch(1); found {
+		t.Fatal("expected no match without a comparator")
+	}
+}
+
+func Test_Cursor_InsertSorted(t *testing.T) {
+	type entry struct {
+		key   int
+		value string
+	}
+
+	c := New([]entry{{1, "a"}, {3, "b"}}, Compare(func(a, b entry) int {
+		return a.key - b.key
+	}))
+
+	err := c.InsertSorted(entry{3, "c"}, entry{0, "d"}, entry{2, "e"}, entry{3, "f"}, entry{9, "g"})
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	var got []string
+	for _, e := range items(c) {
+		got = append(got, e.value)
+	}
+
+	if strings.Join(got, "") != "daebcfg" {
+		t.Fatalf("expected %v, got %v", "daebcfg", strings.Join(got, ""))
+	}
+
+	if err := New([]level{1}).InsertSorted(2); err != ErrNoOrder {
+		t.Fatalf("expected %v, got %v", ErrNoOrder, err)
+	}
+
+	capped := New([]int{1, 2}, Cap[int](3))
+	if err := capped.InsertSorted(0, 4); err != ErrOverflow {
+		t.Fatalf("expected %v, got %v", ErrOverflow, err)
+	}
+}
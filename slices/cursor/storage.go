@@ -0,0 +1,112 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"slices"
+)
+
+// Storage holds the elements of a cursor. The cursor validates every index
+// before calling into its storage, so implementations do not need to
+// check bounds themselves. Implementations must pass the conformance suite
+// in the cursortest package.
+type Storage[T any] interface {
+	// Len returns the number of elements in the storage
+	Len() int
+
+	// At returns the element at index i
+	At(i int) T
+
+	// Set replaces the element at index i
+	Set(i int, v T)
+
+	// Slice returns a copy of the elements from start to end
+	Slice(start, end int) []T
+
+	// Splice replaces the elements from start to end with the values
+	Splice(start, end int, values []T)
+}
+
+// viewer is implemented by storage which can expose a section of its
+// elements without copying them. View returns false if the section is not
+// held contiguously.
+type viewer[T any] interface {
+	View(start, end int) ([]T, bool)
+}
+
+// view returns the elements from start to end of the storage, sharing its
+// memory if the storage allows it and otherwise copying them into buf
+func view[T any](s Storage[T], start, end int, buf []T) []T {
+	if v, ok := s.(viewer[T]); ok {
+		if out, ok := v.View(start, end); ok {
+			return out
+		}
+	}
+
+	buf = buf[:0]
+	for i := start; i < end; i++ {
+		buf = append(buf, s.At(i))
+	}
+
+	return buf
+}
+
+// WithStorage stores the elements of the cursor in the storage returned by
+// fn, which takes ownership of the slice it is given. Cursors derived from
+// the cursor, such as by Take or Copy, use the same kind of storage.
+func WithStorage[T any](fn func(data []T) Storage[T]) Option[T] {
+	return func(c *Cursor[T]) {
+		c.backend = fn
+	}
+}
+
+// sliceStorage is the default storage which holds the elements in a single
+// contiguous slice.
+type sliceStorage[T any] struct {
+	data []T
+}
+
+// SliceStorage returns storage holding the elements in a single contiguous
+// slice. It is the default storage of a cursor and has the fastest reads,
+// while edits cost O(n).
+func SliceStorage[T any](data []T) Storage[T] {
+	return &sliceStorage[T]{data: data}
+}
+
+func (b *sliceStorage[T]) Len() int {
+	return len(b.data)
+}
+
+func (b *sliceStorage[T]) At(i int) T {
+	return b.data[i]
+}
+
+func (b *sliceStorage[T]) Set(i int, v T) {
+	b.data[i] = v
+}
+
+func (b *sliceStorage[T]) Slice(start, end int) []T {
+	out := make([]T, end-start)
+	copy(out, b.data[start:end])
+	return out
+}
+
+func (b *sliceStorage[T]) View(start, end int) ([]T, bool) {
+	return b.data[start:end:end], true
+}
+
+func (b *sliceStorage[T]) Splice(start, end int, values []T) {
+	b.data = slices.Replace(b.data, start, end, values...)
+}
@@ -0,0 +1,37 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor_test
+
+import (
+	"testing"
+
+	"go.devnw.com/ds/slices/cursor"
+	"go.devnw.com/ds/slices/cursor/cursortest"
+)
+
+func Test_Storage_Conformance(t *testing.T) {
+	backends := map[string]func([]int) cursor.Storage[int]{
+		"slice":   cursor.SliceStorage[int],
+		"gap":     cursor.GapStorage[int],
+		"ring":    cursor.RingStorage[int],
+		"chunked": cursor.ChunkedStorage[int],
+	}
+
+	for name, fn := range backends {
+		t.Run(name, func(t *testing.T) {
+			cursortest.TestStorage(t, fn)
+		})
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"iter"
+)
+
+// ErrLookback is returned when a Stream is asked for an element which has
+// already fallen out of its lookback window.
+var ErrLookback = errors.New("position outside of lookback window")
+
+// Stream is a cursor which lazily pulls its elements from a source, such
+// as an io.Reader, rather than holding them all in memory. It retains a
+// lookback window of elements so that Prev and Seek can move back at most
+// window elements behind the furthest position the cursor has reached.
+// Moving back further returns ErrLookback.
+//
+// Like Text, the position of a Stream may rest on the end of the source,
+// where methods which read the element at the cursor return io.EOF.
+// Errors from the source other than io.EOF are returned as they are and
+// are also reported by Err.
+type Stream[T any] struct {
+	next func() (T, error)
+	err  error
+
+	// buff holds the elements read from the source which are still
+	// retained, with base the index of the first of them
+	buff []T
+	base int
+
+	pos    int
+	far    int
+	window int
+}
+
+// NewStream creates a new Stream which pulls elements from next until it
+// returns an error, with io.EOF marking the end of the source. The value
+// returned alongside an error is discarded. A window of less than one is
+// treated as one.
+func NewStream[T any](next func() (T, error), window int) *Stream[T] {
+	return &Stream[T]{
+		next:   next,
+		window: max(window, 1),
+	}
+}
+
+// NewByteStream creates a new Stream over the bytes of r
+func NewByteStream(r io.Reader, window int) *Stream[byte] {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	return NewStream(br.ReadByte, window)
+}
+
+// NewRuneStream creates a new Stream over the UTF-8 encoded runes of r.
+// Invalid UTF-8 is decoded as utf8.RuneError one byte at a time.
+func NewRuneStream(r io.Reader, window int) *Stream[rune] {
+	rr, ok := r.(io.RuneReader)
+	if !ok {
+		rr = bufio.NewReader(r)
+	}
+
+	return NewStream(func() (rune, error) {
+		v, _, err := rr.ReadRune()
+		return v, err
+	}, window)
+}
+
+// Pos returns the position of the cursor
+func (s *Stream[T]) Pos() int {
+	return s.pos
+}
+
+// Err returns the first error other than io.EOF returned by the source
+func (s *Stream[T]) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+
+	return s.err
+}
+
+// Get returns the element at the cursor
+func (s *Stream[T]) Get() (T, error) {
+	return s.at(s.pos, s.base)
+}
+
+// Next moves the cursor forward and returns the element there
+func (s *Stream[T]) Next() (T, error) {
+	return s.Seek(s.pos + 1)
+}
+
+// Prev moves the cursor back and returns the element there
+func (s *Stream[T]) Prev() (T, error) {
+	return s.Seek(s.pos - 1)
+}
+
+// Seek moves the cursor to pos and returns the element there, reading
+// from the source as needed. Seeking to the end of the source moves the
+// cursor there and returns io.EOF.
+//
+// Seeking forward releases the elements which will fall out of the
+// lookback window as it reads, so a Seek which fails part way, such as
+// one past the end of the source, may leave the elements behind the
+// cursor out of the window.
+func (s *Stream[T]) Seek(pos int) (T, error) {
+	v, err := s.at(pos, pos-s.window)
+	if err == nil || err == io.EOF {
+		s.move(pos)
+	}
+
+	return v, err
+}
+
+// Peek returns the element n elements away from the cursor without moving
+// it. A negative n looks behind the cursor.
+func (s *Stream[T]) Peek(n int) (T, error) {
+	return s.at(s.pos+n, s.base)
+}
+
+// Take returns the next i elements from the cursor and moves the cursor
+// past them. If the source ends first it returns ErrUnderflow and the
+// cursor is left where it was.
+func (s *Stream[T]) Take(i int) ([]T, error) {
+	if i < 0 {
+		return nil, ErrIndexOutOfRange
+	}
+
+	if i > 0 {
+		err := s.fill(s.pos+i-1, s.base)
+		if err == io.EOF {
+			return nil, ErrUnderflow
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	out := make([]T, i)
+	copy(out, s.buff[s.pos-s.base:])
+	s.move(s.pos + i)
+
+	return out, nil
+}
+
+// Forward returns an iterator over the index and element of the stream
+// from the current position to the end of the source, advancing the
+// cursor as it goes. The iteration stops early if the source fails, which
+// is reported by Err.
+func (s *Stream[T]) Forward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := s.pos; ; i++ {
+			v, err := s.at(i, s.base)
+			if err != nil {
+				return
+			}
+
+			s.move(i)
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// at returns the element at pos, reading from the source as needed and
+// releasing any elements before keep as it goes
+func (s *Stream[T]) at(pos, keep int) (T, error) {
+	var out T
+
+	if pos < 0 {
+		return out, ErrIndexOutOfRange
+	}
+
+	if pos < s.far-s.window || pos < s.base {
+		return out, ErrLookback
+	}
+
+	err := s.fill(pos, keep)
+	if err == io.EOF && pos > s.base+len(s.buff) {
+		return out, ErrIndexOutOfRange
+	}
+
+	if err != nil {
+		return out, err
+	}
+
+	return s.buff[pos-s.base], nil
+}
+
+// fill reads from the source until the element at pos has been read. The
+// elements before keep are released whenever the buffer is full, so that
+// reading far ahead holds no more than the elements from keep onwards.
+func (s *Stream[T]) fill(pos, keep int) error {
+	for s.base+len(s.buff) <= pos {
+		if s.err != nil {
+			return s.err
+		}
+
+		v, err := s.next()
+		if err != nil {
+			s.err = err
+			return err
+		}
+
+		if len(s.buff) == cap(s.buff) {
+			s.release(min(keep-s.base, len(s.buff)))
+		}
+
+		s.buff = append(s.buff, v)
+	}
+
+	return nil
+}
+
+// move moves the cursor to pos and releases the elements which have
+// fallen out of the lookback window
+func (s *Stream[T]) move(pos int) {
+	s.pos = pos
+	if pos <= s.far {
+		return
+	}
+
+	s.far = pos
+
+	// Only compact once a full window can be released so the cost of
+	// copying the retained elements down is amortized
+	drop := min(s.far-s.window-s.base, len(s.buff))
+	if drop >= s.window {
+		s.release(drop)
+	}
+}
+
+// release discards the first n retained elements, reallocating the buffer
+// once it has grown much larger than the elements it still holds, such as
+// after a large Take
+func (s *Stream[T]) release(n int) {
+	if n <= 0 {
+		return
+	}
+
+	m := copy(s.buff, s.buff[n:])
+	clear(s.buff[m:])
+	s.buff = s.buff[:m]
+	s.base += n
+
+	if size := 2 * max(m, s.window); cap(s.buff) > 2*size {
+		s.buff = append(make([]T, 0, size), s.buff...)
+	}
+}
@@ -0,0 +1,239 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// counter returns a source of the integers from 0 to n
+func counter(n int) func() (int, error) {
+	i := 0
+	return func() (int, error) {
+		if i >= n {
+			return 0, io.EOF
+		}
+
+		i++
+		return i - 1, nil
+	}
+}
+
+func Test_Stream_Seek(t *testing.T) {
+	tests := []struct {
+		seek []int
+		want int
+		pos  int
+		err  error
+	}{
+		{[]int{0}, 0, 0, nil},
+		{[]int{5}, 5, 5, nil},
+		{[]int{9}, 9, 9, nil},
+		{[]int{10}, 0, 10, io.EOF},
+		{[]int{11}, 0, 0, ErrIndexOutOfRange},
+		{[]int{-1}, 0, 0, ErrIndexOutOfRange},
+		{[]int{5, 2}, 2, 2, nil},
+		{[]int{5, 1}, 0, 5, ErrLookback},
+		{[]int{5, 2, 1}, 0, 2, ErrLookback},
+		{[]int{9, 10, 7}, 7, 7, nil},
+		{[]int{9, 10, 6}, 0, 10, ErrLookback},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			s := NewStream(counter(10), 3)
+
+			var got int
+			var err error
+			for _, pos := range tt.seek {
+				got, err = s.Seek(pos)
+			}
+
+			if err != tt.err {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+
+			if s.Pos() != tt.pos {
+				t.Fatalf("expected %v, got %v", tt.pos, s.Pos())
+			}
+		})
+	}
+}
+
+func Test_Stream_Runes(t *testing.T) {
+	s := NewRuneStream(iotest.OneByteReader(strings.NewReader("aé世\xff")), 1)
+
+	var got []rune
+	for _, r := range s.Forward() {
+		got = append(got, r)
+	}
+
+	diff := cmp.Diff(got, []rune{'a', 'é', '世', '�'})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	r, err := s.Prev()
+	if err != nil || r != '世' {
+		t.Fatalf("expected %q, got %q (%v)", '世', r, err)
+	}
+
+	_, err = s.Prev()
+	if err != ErrLookback {
+		t.Fatalf("expected %v, got %v", ErrLookback, err)
+	}
+}
+
+func Test_Stream_Bytes_Take(t *testing.T) {
+	s := NewByteStream(strings.NewReader("hello world"), 4)
+
+	got, err := s.Take(5)
+	if err != nil || string(got) != "hello" {
+		t.Fatalf("expected %q, got %q (%v)", "hello", got, err)
+	}
+
+	b, err := s.Peek(1)
+	if err != nil || b != 'w' {
+		t.Fatalf("expected %q, got %q (%v)", 'w', b, err)
+	}
+
+	b, err = s.Peek(-2)
+	if err != nil || b != 'l' {
+		t.Fatalf("expected %q, got %q (%v)", 'l', b, err)
+	}
+
+	_, err = s.Take(10)
+	if err != ErrUnderflow {
+		t.Fatalf("expected %v, got %v", ErrUnderflow, err)
+	}
+
+	if s.Pos() != 5 {
+		t.Fatalf("expected %v, got %v", 5, s.Pos())
+	}
+
+	got, err = s.Take(6)
+	if err != nil || string(got) != " world" {
+		t.Fatalf("expected %q, got %q (%v)", " world", got, err)
+	}
+
+	_, err = s.Get()
+	if err != io.EOF {
+		t.Fatalf("expected %v, got %v", io.EOF, err)
+	}
+}
+
+func Test_Stream_SourceError(t *testing.T) {
+	failure := errors.New("failure")
+	s := NewByteStream(io.MultiReader(
+		strings.NewReader("ab"),
+		iotest.ErrReader(failure),
+	), 4)
+
+	var got []byte
+	for _, b := range s.Forward() {
+		got = append(got, b)
+	}
+
+	if string(got) != "ab" {
+		t.Fatalf("expected %q, got %q", "ab", got)
+	}
+
+	if s.Err() != failure {
+		t.Fatalf("expected %v, got %v", failure, s.Err())
+	}
+
+	_, err := s.Next()
+	if err != failure {
+		t.Fatalf("expected %v, got %v", failure, err)
+	}
+
+	// The elements already read are still available
+	b, err := s.Prev()
+	if err != nil || b != 'a' {
+		t.Fatalf("expected %q, got %q (%v)", 'a', b, err)
+	}
+}
+
+func Test_Stream_Bounded(t *testing.T) {
+	const window = 8
+
+	s := NewStream(counter(100_000), window)
+
+	for i, v := range s.Forward() {
+		if i != v {
+			t.Fatalf("expected %v, got %v", i, v)
+		}
+
+		if len(s.buff) > 2*window+1 {
+			t.Fatalf("expected at most %v elements, got %v", 2*window+1, len(s.buff))
+		}
+
+		if i >= window && i%97 == 0 {
+			v, err := s.Peek(-window)
+			if err != nil || v != i-window {
+				t.Fatalf("expected %v, got %v (%v)", i-window, v, err)
+			}
+		}
+	}
+
+	if s.Err() != nil {
+		t.Fatalf("expected %v, got %v", nil, s.Err())
+	}
+}
+
+func Test_Stream_Seek_Bounded(t *testing.T) {
+	const window = 4
+
+	s := NewStream(counter(1_000_000), window)
+
+	v, err := s.Seek(999_000)
+	if err != nil || v != 999_000 {
+		t.Fatalf("expected %v, got %v (%v)", 999_000, v, err)
+	}
+
+	if cap(s.buff) > 8*window {
+		t.Fatalf("expected at most %v elements, got %v", 8*window, cap(s.buff))
+	}
+
+	v, err = s.Peek(-window)
+	if err != nil || v != 999_000-window {
+		t.Fatalf("expected %v, got %v (%v)", 999_000-window, v, err)
+	}
+
+	// The buffer grown by a large Take is given back once the cursor
+	// moves on
+	if _, err = s.Take(500); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	for range 2 * window {
+		_, _ = s.Next()
+	}
+
+	if cap(s.buff) > 8*window {
+		t.Fatalf("expected at most %v elements, got %v", 8*window, cap(s.buff))
+	}
+}
@@ -0,0 +1,403 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"iter"
+	"sync"
+)
+
+// Sync is a Cursor which is safe for concurrent use. Every method is
+// guarded by a read/write lock, and compound operations such as TakeNext
+// are applied atomically so callers do not need any external locking.
+type Sync[T any] struct {
+	mu sync.RWMutex
+	c  *Cursor[T]
+}
+
+// NewSync creates a new synchronized cursor over a copy of the buffer
+func NewSync[T any](buff []T, opts ...Option[T]) *Sync[T] {
+	return &Sync[T]{c: New(buff, opts...)}
+}
+
+func (s *Sync[T]) wrap(c *Cursor[T], err error) (*Sync[T], error) {
+	if c == nil {
+		return nil, err
+	}
+
+	return &Sync[T]{c: c}, err
+}
+
+// Do calls fn with the underlying cursor while holding the write lock,
+// allowing arbitrary compound operations to be applied atomically. The
+// cursor must not be retained or used once fn returns.
+func (s *Sync[T]) Do(fn func(c *Cursor[T]) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return fn(s.c)
+}
+
+// Read calls fn with the underlying cursor while holding the read lock.
+// fn must not modify the cursor, including its position.
+func (s *Sync[T]) Read(fn func(c *Cursor[T]) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return fn(s.c)
+}
+
+// TakeNext returns the next i elements starting at the current position
+// and advances the cursor past them in a single operation. If there are
+// not enough elements ErrUnderflow is returned and the cursor is left
+// unchanged.
+func (s *Sync[T]) TakeNext(i int) ([]T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if i < 0 {
+		return nil, ErrIndexOutOfRange
+	}
+
+	if s.c.pos+i > s.c.store().Len() {
+		return nil, ErrUnderflow
+	}
+
+	out := s.c.store().Slice(s.c.pos, s.c.pos+i)
+	s.c.move(s.c.pos + i)
+
+	return out, nil
+}
+
+// TakeRem returns the remaining elements of the cursor and advances the
+// cursor past them in a single operation.
+func (s *Sync[T]) TakeRem() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := s.c.Rem()
+	s.c.move(s.c.store().Len())
+
+	return out
+}
+
+func (s *Sync[T]) Slice(start, end int) ([]T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.c.Slice(start, end)
+}
+
+func (s *Sync[T]) Chop(start, end int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.c.Chop(start, end)
+}
+
+func (s *Sync[T]) First() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.c.First()
+}
+
+func (s *Sync[T]) Last() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.c.Last()
+}
+
+// IterFn calls f with each element from the current position onwards.
+// The lock is released while f runs so f may call back into the cursor.
+func (s *Sync[T]) IterFn(f func(T) error) error {
+	if _, err := s.Get(); err != nil {
+		return err
+	}
+
+	for _, v := range s.Forward() {
+		err := f(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Sync[T]) Cap() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.c.Cap()
+}
+
+func (s *Sync[T]) Available() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.c.Available()
+}
+
+func (s *Sync[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.c.Len()
+}
+
+func (s *Sync[T]) Pos() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.c.Pos()
+}
+
+func (s *Sync[T]) Less(i, j int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.c.Less(i, j)
+}
+
+func (s *Sync[T]) Swap(i, j int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.c.Swap(i, j)
+}
+
+func (s *Sync[T]) Next() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.c.Next()
+}
+
+func (s *Sync[T]) Prev() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.c.Prev()
+}
+
+func (s *Sync[T]) Get() (T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Read directly rather than through Seek which writes the position
+	if s.c.validPOS() {
+		return s.c.store().At(s.c.pos), nil
+	}
+
+	var out T
+	return out, ErrIndexOutOfRange
+}
+
+func (s *Sync[T]) Seek(pos int) (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.c.Seek(pos)
+}
+
+func (s *Sync[T]) Set(v T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.c.Set(v)
+}
+
+func (s *Sync[T]) Skip(i int) (*Sync[T], error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.wrap(s.c.Skip(i))
+}
+
+func (s *Sync[T]) Rem() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.c.Rem()
+}
+
+func (s *Sync[T]) Take(i int) ([]T, *Sync[T], error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	out, rem, err := s.c.Take(i)
+	if rem == s.c {
+		return out, s, err
+	}
+
+	return out, &Sync[T]{c: rem}, err
+}
+
+func (s *Sync[T]) Copy() *Sync[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &Sync[T]{c: s.c.Copy()}
+}
+
+func (s *Sync[T]) Replace(values ...T) (*Sync[T], error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.wrap(s.c.Replace(values...))
+}
+
+func (s *Sync[T]) ReplaceAt(pos int, values ...T) (*Sync[T], error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.wrap(s.c.ReplaceAt(pos, values...))
+}
+
+func (s *Sync[T]) Delete() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.c.Delete()
+}
+
+func (s *Sync[T]) DeleteAt(pos int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.c.DeleteAt(pos)
+}
+
+func (s *Sync[T]) Insert(values ...T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.c.Insert(values...)
+}
+
+func (s *Sync[T]) InsertAt(pos int, values ...T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.c.InsertAt(pos, values...)
+}
+
+func (s *Sync[T]) Append(values ...T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.c.Append(values...)
+}
+
+func (s *Sync[T]) Prepend(values ...T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.c.Prepend(values...)
+}
+
+func (s *Sync[T]) Sort() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.c.Sort()
+}
+
+func (s *Sync[T]) SortStable() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.c.SortStable()
+}
+
+func (s *Sync[T]) IsSorted() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.c.IsSorted()
+}
+
+func (s *Sync[T]) BinarySearch(v T) (int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.c.BinarySearch(v)
+}
+
+func (s *Sync[T]) InsertSorted(values ...T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.c.InsertSorted(values...)
+}
+
+// step moves the cursor to pos and returns the element there, holding the
+// write lock only for the duration of the move.
+func (s *Sync[T]) step(pos int) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.c.isValidPOS(pos) {
+		var out T
+		return out, false
+	}
+
+	s.c.move(pos)
+	return s.c.store().At(pos), true
+}
+
+// All returns an iterator over every index and element of the cursor. The
+// lock is only held while moving between elements, so the loop body may
+// call back into the cursor.
+func (s *Sync[T]) All() iter.Seq2[int, T] {
+	return s.walk(func() int { return 0 }, 1)
+}
+
+// Forward returns an iterator from the current position to the end of the
+// buffer. The lock is only held while moving between elements.
+func (s *Sync[T]) Forward() iter.Seq2[int, T] {
+	return s.walk(s.Pos, 1)
+}
+
+// Backward returns an iterator from the current position to the start of
+// the buffer. The lock is only held while moving between elements.
+func (s *Sync[T]) Backward() iter.Seq2[int, T] {
+	return s.walk(s.Pos, -1)
+}
+
+// Remaining returns an iterator over the elements from the current
+// position to the end of the buffer.
+func (s *Sync[T]) Remaining() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.Forward() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+func (s *Sync[T]) walk(start func() int, dir int) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := start(); ; i += dir {
+			v, ok := s.step(i)
+			if !ok || !yield(i, v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Sync_TakeNext(t *testing.T) {
+	tests := []struct {
+		data []int
+		pos  int
+		take int
+		want []int
+		next int
+		err  error
+	}{
+		{[]int{1, 2, 3, 4, 5}, 0, 3, []int{1, 2, 3}, 3, nil},
+		{[]int{1, 2, 3, 4, 5}, 2, 3, []int{3, 4, 5}, 5, nil},
+		{[]int{1, 2, 3, 4, 5}, 0, 0, []int{}, 0, nil},
+		{[]int{1, 2, 3, 4, 5}, 3, 3, nil, 3, ErrUnderflow},
+		{[]int{1, 2, 3, 4, 5}, 0, -1, nil, 0, ErrIndexOutOfRange},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			s := NewSync(tt.data)
+			s.c.pos = tt.pos
+
+			got, err := s.TakeNext(tt.take)
+			if err != tt.err {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			diff := cmp.Diff(got, tt.want)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			if s.Pos() != tt.next {
+				t.Fatalf("expected %v, got %v", tt.next, s.Pos())
+			}
+		})
+	}
+}
+
+func Test_Sync_TakeRem(t *testing.T) {
+	s := NewSync([]int{1, 2, 3, 4, 5})
+	s.c.pos = 2
+
+	diff := cmp.Diff(s.TakeRem(), []int{3, 4, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if len(s.TakeRem()) != 0 {
+		t.Fatal("expected no remaining elements")
+	}
+
+	s.Append(6)
+
+	diff = cmp.Diff(s.TakeRem(), []int{6})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Sync_Iterators(t *testing.T) {
+	s := NewSync([]int{1, 2, 3, 4, 5})
+
+	var got []int
+	for i, v := range s.All() {
+		got = append(got, v)
+
+		// Calling back into the cursor must not deadlock
+		if s.Pos() != i {
+			t.Fatalf("expected %v, got %v", i, s.Pos())
+		}
+	}
+
+	diff := cmp.Diff(got, []int{1, 2, 3, 4, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	got = got[:0]
+	for _, v := range s.Backward() {
+		got = append(got, v)
+	}
+
+	diff = cmp.Diff(got, []int{5, 4, 3, 2, 1})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	s.c.pos = 3
+	diff = cmp.Diff(slices.Collect(s.Remaining()), []int{4, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Sync_Do(t *testing.T) {
+	s := NewSync([]int{1, 2, 3})
+
+	err := s.Do(func(c *Cursor[int]) error {
+		c.Append(4, 5)
+		_, err := c.Last()
+		return err
+	})
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	var rem []int
+	_ = s.Read(func(c *Cursor[int]) error {
+		rem = c.Rem()
+		return nil
+	})
+
+	diff := cmp.Diff(rem, []int{5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Sync_Take_Error(t *testing.T) {
+	s := NewSync([]int{1, 2, 3})
+
+	_, rem, err := s.Take(4)
+	if err != ErrUnderflow {
+		t.Fatalf("expected %v, got %v", ErrUnderflow, err)
+	}
+
+	if rem != s {
+		t.Fatal("expected the same cursor on error")
+	}
+
+	_, rem, err = s.Take(1)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(rem.Rem(), []int{2, 3})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+// Test_Sync_Stress runs a producer appending to the cursor alongside
+// several consumers taking from it. Run with -race to check for data races.
+func Test_Sync_Stress(t *testing.T) {
+	const total = 10_000
+	const batch = 7
+
+	s := NewSync[int](nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < total; i += batch {
+			var values []int
+			for j := i; j < i+batch && j < total; j++ {
+				values = append(values, j)
+			}
+
+			s.Append(values...)
+		}
+	}()
+
+	consumers := runtime.GOMAXPROCS(0) + 1
+	results := make([][]int, consumers)
+	var taken sync.WaitGroup
+
+	for i := range consumers {
+		taken.Add(1)
+		go func() {
+			defer taken.Done()
+
+			for {
+				if s.Pos() >= total {
+					return
+				}
+
+				values, err := s.TakeNext(1)
+				if err != nil {
+					runtime.Gosched()
+					continue
+				}
+
+				results[i] = append(results[i], values...)
+
+				// Exercise the readers alongside the writers
+				_ = s.Len()
+				_, _ = s.Get()
+				_, _ = s.Slice(0, 0)
+			}
+		}()
+	}
+
+	wg.Wait()
+	taken.Wait()
+
+	var all []int
+	for _, r := range results {
+		all = append(all, r...)
+	}
+
+	slices.Sort(all)
+	if len(all) != total {
+		t.Fatalf("expected %v, got %v", total, len(all))
+	}
+
+	for i, v := range all {
+		if v != i {
+			t.Fatalf("expected %v, got %v", i, v)
+		}
+	}
+}
+
+func Test_Sync_Stress_Mixed(t *testing.T) {
+	s := NewSync([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for j := range 500 {
+				switch (i + j) % 8 {
+				case 0:
+					s.Append(j)
+				case 1:
+					_ = s.Insert(j)
+				case 2:
+					s.Delete()
+				case 3:
+					_, _ = s.Next()
+				case 4:
+					_, _ = s.Prev()
+				case 5:
+					s.Set(j)
+				case 6:
+					_ = s.Copy().Rem()
+				default:
+					for range s.Forward() {
+						break
+					}
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if s.Len() < 0 {
+		t.Fatal("expected a valid length")
+	}
+}
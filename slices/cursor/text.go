@@ -0,0 +1,247 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Position is a location within the input of a Text cursor.
+type Position struct {
+	// Offset is the byte offset from the start of the input
+	Offset int
+
+	// Line is the line number, starting at 1
+	Line int
+
+	// Column is the rune offset from the start of the line, starting at 1
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Span is the range of input between two positions, with End exclusive.
+type Span struct {
+	Start Position
+	End   Position
+}
+
+func (s Span) String() string {
+	return fmt.Sprintf("%s-%s", s.Start, s.End)
+}
+
+// Text is a rune cursor over UTF-8 encoded input intended for hand-written
+// lexers. It tracks the line, column and byte offset of the cursor and
+// provides the lookahead and token helpers lexers commonly need.
+//
+// Unlike Cursor, the position of a Text ranges from 0 to Len inclusive
+// where Len is the end of the input. Methods which read the rune at the end
+// of the input return io.EOF.
+type Text struct {
+	src string
+	c   *Cursor[rune]
+
+	// offsets holds the byte offset of every rune along with the length of
+	// the input so the offset of the end position can be looked up
+	offsets []int
+
+	// lines holds the rune index at which each line starts
+	lines []int
+
+	mark int
+}
+
+// NewText creates a new Text cursor over the input. Invalid UTF-8 is
+// decoded as utf8.RuneError one byte at a time.
+func NewText(input string) *Text {
+	runes := make([]rune, 0, len(input))
+	offsets := make([]int, 0, len(input)+1)
+	lines := []int{0}
+
+	for off := 0; off < len(input); {
+		r, size := utf8.DecodeRuneInString(input[off:])
+
+		runes = append(runes, r)
+		offsets = append(offsets, off)
+		off += size
+
+		if r == '\n' {
+			lines = append(lines, len(runes))
+		}
+	}
+
+	offsets = append(offsets, len(input))
+
+	return &Text{
+		src:     input,
+		c:       New(runes),
+		offsets: offsets,
+		lines:   lines,
+	}
+}
+
+// Len returns the number of runes in the input
+func (t *Text) Len() int {
+	return t.c.Len()
+}
+
+// Pos returns the rune index of the cursor
+func (t *Text) Pos() int {
+	return t.c.pos
+}
+
+// Get returns the rune at the cursor
+func (t *Text) Get() (rune, error) {
+	return t.Peek(0)
+}
+
+// Next moves the cursor forward one rune and returns the rune there
+func (t *Text) Next() (rune, error) {
+	return t.Seek(t.c.pos + 1)
+}
+
+// Prev moves the cursor back one rune and returns the rune there
+func (t *Text) Prev() (rune, error) {
+	return t.Seek(t.c.pos - 1)
+}
+
+// Seek moves the cursor to the given rune index and returns the rune
+// there. Seeking to Len moves the cursor to the end of the input and
+// returns io.EOF.
+func (t *Text) Seek(pos int) (rune, error) {
+	if pos < 0 || pos > t.c.Len() {
+		return 0, ErrIndexOutOfRange
+	}
+
+	t.c.pos = pos
+	return t.Get()
+}
+
+// Peek returns the rune n runes away from the cursor without moving it. A
+// negative n looks behind the cursor.
+func (t *Text) Peek(n int) (rune, error) {
+	pos := t.c.pos + n
+	if pos == t.c.Len() {
+		return 0, io.EOF
+	}
+
+	if !t.c.isValidPOS(pos) {
+		return 0, ErrIndexOutOfRange
+	}
+
+	return t.c.store().At(pos), nil
+}
+
+// EOF reports whether the cursor is at the end of the input
+func (t *Text) EOF() bool {
+	return t.c.pos >= t.c.Len()
+}
+
+// Accept moves past the rune at the cursor if it is in the set
+func (t *Text) Accept(set string) bool {
+	r, err := t.Get()
+	if err != nil || !strings.ContainsRune(set, r) {
+		return false
+	}
+
+	t.c.pos++
+	return true
+}
+
+// AcceptRun moves past every rune in the set and returns the number of
+// runes accepted
+func (t *Text) AcceptRun(set string) int {
+	count := 0
+	for t.Accept(set) {
+		count++
+	}
+
+	return count
+}
+
+// Until moves the cursor forward until the rune at the cursor satisfies
+// the predicate or the end of the input is reached, returning the text
+// which was passed over.
+func (t *Text) Until(pred func(rune) bool) string {
+	start := t.c.pos
+	for t.c.validPOS() && !pred(t.c.store().At(t.c.pos)) {
+		t.c.pos++
+	}
+
+	return t.text(start, t.c.pos)
+}
+
+// Mark records the current position as the start of a token
+func (t *Text) Mark() {
+	t.mark = t.c.pos
+}
+
+// Reset moves the cursor back to the last mark
+func (t *Text) Reset() {
+	t.c.pos = t.mark
+}
+
+// Token returns the text between the last mark and the cursor
+func (t *Text) Token() string {
+	return t.text(t.mark, t.c.pos)
+}
+
+// Span returns the span between the last mark and the cursor
+func (t *Text) Span() Span {
+	return t.SpanOf(t.mark, t.c.pos)
+}
+
+// Position returns the position of the cursor
+func (t *Text) Position() Position {
+	return t.PositionAt(t.c.pos)
+}
+
+// PositionAt returns the position of the given rune index, which is
+// clamped to the bounds of the input
+func (t *Text) PositionAt(pos int) Position {
+	pos = max(0, min(pos, t.c.Len()))
+
+	// Find the last line which starts at or before the position
+	line := sort.SearchInts(t.lines, pos+1) - 1
+
+	return Position{
+		Offset: t.offsets[pos],
+		Line:   line + 1,
+		Column: pos - t.lines[line] + 1,
+	}
+}
+
+// SpanOf returns the span between the given rune indexes
+func (t *Text) SpanOf(start, end int) Span {
+	return Span{
+		Start: t.PositionAt(start),
+		End:   t.PositionAt(end),
+	}
+}
+
+// text returns the input between the given rune indexes
+func (t *Text) text(start, end int) string {
+	if start > end {
+		start, end = end, start
+	}
+
+	return t.src[t.offsets[start]:t.offsets[end]]
+}
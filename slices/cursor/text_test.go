@@ -0,0 +1,232 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"unicode"
+)
+
+func Test_Text_PositionAt(t *testing.T) {
+	// "é" is two bytes and "世" is three
+	text := NewText("ab\né世\n\nx")
+
+	tests := []struct {
+		pos  int
+		want Position
+	}{
+		{0, Position{Offset: 0, Line: 1, Column: 1}},
+		{2, Position{Offset: 2, Line: 1, Column: 3}},
+		{3, Position{Offset: 3, Line: 2, Column: 1}},
+		{4, Position{Offset: 5, Line: 2, Column: 2}},
+		{5, Position{Offset: 8, Line: 2, Column: 3}},
+		{6, Position{Offset: 9, Line: 3, Column: 1}},
+		{7, Position{Offset: 10, Line: 4, Column: 1}},
+		{8, Position{Offset: 11, Line: 4, Column: 2}},
+		{100, Position{Offset: 11, Line: 4, Column: 2}},
+		{-1, Position{Offset: 0, Line: 1, Column: 1}},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			got := text.PositionAt(tt.pos)
+			if got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_Text_Navigation(t *testing.T) {
+	text := NewText("a\nβ")
+
+	r, err := text.Get()
+	if err != nil || r != 'a' {
+		t.Fatalf("expected %q, got %q (%v)", 'a', r, err)
+	}
+
+	r, err = text.Next()
+	if err != nil || r != '\n' {
+		t.Fatalf("expected %q, got %q (%v)", '\n', r, err)
+	}
+
+	r, err = text.Next()
+	if err != nil || r != 'β' {
+		t.Fatalf("expected %q, got %q (%v)", 'β', r, err)
+	}
+
+	if got := text.Position(); got != (Position{Offset: 2, Line: 2, Column: 1}) {
+		t.Fatalf("expected %v, got %v", "2:1", got)
+	}
+
+	_, err = text.Next()
+	if err != io.EOF {
+		t.Fatalf("expected %v, got %v", io.EOF, err)
+	}
+
+	if !text.EOF() {
+		t.Fatal("expected the cursor to be at the end of the input")
+	}
+
+	if got := text.Position(); got != (Position{Offset: 4, Line: 2, Column: 2}) {
+		t.Fatalf("expected %v, got %v", "2:2", got)
+	}
+
+	_, err = text.Next()
+	if err != ErrIndexOutOfRange {
+		t.Fatalf("expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+
+	r, err = text.Prev()
+	if err != nil || r != 'β' {
+		t.Fatalf("expected %q, got %q (%v)", 'β', r, err)
+	}
+
+	r, err = text.Seek(0)
+	if err != nil || r != 'a' {
+		t.Fatalf("expected %q, got %q (%v)", 'a', r, err)
+	}
+
+	_, err = text.Prev()
+	if err != ErrIndexOutOfRange {
+		t.Fatalf("expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+}
+
+func Test_Text_Peek(t *testing.T) {
+	text := NewText("abc")
+	_, _ = text.Seek(1)
+
+	tests := []struct {
+		n    int
+		want rune
+		err  error
+	}{
+		{-1, 'a', nil},
+		{0, 'b', nil},
+		{1, 'c', nil},
+		{2, 0, io.EOF},
+		{3, 0, ErrIndexOutOfRange},
+		{-2, 0, ErrIndexOutOfRange},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			got, err := text.Peek(tt.n)
+			if err != tt.err {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+
+			if text.Pos() != 1 {
+				t.Fatalf("expected %v, got %v", 1, text.Pos())
+			}
+		})
+	}
+}
+
+func Test_Text_Lex(t *testing.T) {
+	const digits = "0123456789"
+
+	text := NewText("let x = 42\nlet yé = 7")
+
+	type token struct {
+		value string
+		span  string
+	}
+
+	var got []token
+	for !text.EOF() {
+		text.AcceptRun(" \n")
+		text.Mark()
+
+		switch {
+		case text.AcceptRun(digits) > 0:
+		case text.Accept("="):
+		default:
+			text.Until(func(r rune) bool {
+				return unicode.IsSpace(r) || r == '='
+			})
+		}
+
+		got = append(got, token{text.Token(), text.Span().String()})
+	}
+
+	want := []token{
+		{"let", "1:1-1:4"},
+		{"x", "1:5-1:6"},
+		{"=", "1:7-1:8"},
+		{"42", "1:9-1:11"},
+		{"let", "2:1-2:4"},
+		{"yé", "2:5-2:7"},
+		{"=", "2:8-2:9"},
+		{"7", "2:10-2:11"},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want[i], got[i])
+		}
+	}
+}
+
+func Test_Text_Reset(t *testing.T) {
+	text := NewText("abc def")
+
+	text.Mark()
+	if text.AcceptRun("abcdef") != 3 {
+		t.Fatalf("expected %v, got %v", 3, text.Pos())
+	}
+
+	if text.Token() != "abc" {
+		t.Fatalf("expected %q, got %q", "abc", text.Token())
+	}
+
+	text.Reset()
+	if text.Pos() != 0 {
+		t.Fatalf("expected %v, got %v", 0, text.Pos())
+	}
+
+	if text.Accept("xyz") {
+		t.Fatal("expected no rune to be accepted")
+	}
+}
+
+func Test_Text_InvalidUTF8(t *testing.T) {
+	text := NewText("a\xffb")
+
+	if text.Len() != 3 {
+		t.Fatalf("expected %v, got %v", 3, text.Len())
+	}
+
+	r, _ := text.Next()
+	if r != unicode.ReplacementChar {
+		t.Fatalf("expected %q, got %q", unicode.ReplacementChar, r)
+	}
+
+	_, _ = text.Next()
+	if got := text.Position(); got.Offset != 2 {
+		t.Fatalf("expected %v, got %v", 2, got.Offset)
+	}
+}
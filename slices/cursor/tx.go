@@ -0,0 +1,192 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"errors"
+	"slices"
+)
+
+var ErrTxDone = errors.New("transaction has already been committed or rolled back")
+
+// Tx buffers edits to a cursor so that they are applied all at once by
+// Commit or not at all. Each edit is checked when it is made against the
+// buffer as it will be after the edits before it, with the same rules as
+// the matching Cursor method, so that an invalid edit fails straight away
+// rather than part way through the commit.
+//
+// The cursor is not locked while the transaction is open, and edits made
+// to it directly in the meantime are not seen by the transaction. Commit
+// checks the buffered edits again against the cursor as it is then.
+type Tx[T any] struct {
+	c    *Cursor[T]
+	ops  []txOp[T]
+	len  int
+	done bool
+}
+
+// txOp is a buffered replacement of the elements from start to end
+type txOp[T any] struct {
+	start  int
+	end    int
+	values []T
+}
+
+// Begin starts a transaction over the cursor
+func (c *Cursor[T]) Begin() *Tx[T] {
+	return &Tx[T]{c: c, len: c.store().Len()}
+}
+
+// Len returns the length the buffer will have once the transaction is
+// committed
+func (tx *Tx[T]) Len() int {
+	return tx.len
+}
+
+// Set replaces the element at pos
+func (tx *Tx[T]) Set(pos int, v T) error {
+	if !tx.isValidPOS(pos) {
+		return tx.invalid(ErrIndexOutOfRange)
+	}
+
+	return tx.add(pos, pos+1, []T{v})
+}
+
+// InsertAt inserts the values before the element at pos
+func (tx *Tx[T]) InsertAt(pos int, values ...T) error {
+	if !tx.isValidPOS(pos) {
+		return tx.invalid(ErrIndexOutOfRange)
+	}
+
+	return tx.add(pos, pos, values)
+}
+
+// Append adds the values to the end of the buffer
+func (tx *Tx[T]) Append(values ...T) error {
+	return tx.add(tx.len, tx.len, values)
+}
+
+// DeleteAt removes the element at pos
+func (tx *Tx[T]) DeleteAt(pos int) error {
+	if !tx.isValidPOS(pos) {
+		return tx.invalid(ErrIndexOutOfRange)
+	}
+
+	return tx.add(pos, pos+1, nil)
+}
+
+// Chop removes the elements from start to end
+func (tx *Tx[T]) Chop(start, end int) error {
+	if !tx.isValidPOS(start) || !tx.isValidPOS(end) || start > end {
+		return tx.invalid(ErrIndexOutOfRange)
+	}
+
+	return tx.add(start, end, nil)
+}
+
+// Replace overwrites the elements from pos onwards with the values,
+// returning ErrOverflow if they would run past the end of the buffer
+func (tx *Tx[T]) Replace(pos int, values ...T) error {
+	if !tx.isValidPOS(pos) {
+		return tx.invalid(ErrIndexOutOfRange)
+	}
+
+	if pos+len(values) > tx.len {
+		return tx.invalid(ErrOverflow)
+	}
+
+	return tx.add(pos, pos+len(values), values)
+}
+
+// Commit checks every buffered edit against the cursor and then applies
+// them in order as a single step of the history. If any edit is out of
+// range of the buffer as it would be at that point, or the buffer would
+// grow past its capacity, the error is returned and nothing is applied.
+// The transaction is finished either way.
+func (tx *Tx[T]) Commit() error {
+	if tx.done {
+		return ErrTxDone
+	}
+
+	tx.done = true
+
+	c := tx.c
+	n := c.store().Len()
+	for _, op := range tx.ops {
+		if op.start < 0 || op.end < op.start || op.end > n {
+			return ErrIndexOutOfRange
+		}
+
+		n += len(op.values) - (op.end - op.start)
+		if len(op.values) > op.end-op.start && n > c.cap {
+			return ErrOverflow
+		}
+	}
+
+	return c.Group(func() error {
+		for _, op := range tx.ops {
+			c.splice(op.start, op.end, op.values)
+		}
+
+		return nil
+	})
+}
+
+// Rollback discards the buffered edits, leaving the cursor unchanged
+func (tx *Tx[T]) Rollback() error {
+	if tx.done {
+		return ErrTxDone
+	}
+
+	tx.done = true
+	tx.ops = nil
+	return nil
+}
+
+func (tx *Tx[T]) isValidPOS(pos int) bool {
+	return pos >= 0 && pos < tx.len
+}
+
+// invalid returns the error for an invalid edit, or ErrTxDone if the
+// transaction has finished
+func (tx *Tx[T]) invalid(err error) error {
+	if tx.done {
+		return ErrTxDone
+	}
+
+	return err
+}
+
+// add buffers the replacement of the elements from start to end with a
+// copy of the values
+func (tx *Tx[T]) add(start, end int, values []T) error {
+	if tx.done {
+		return ErrTxDone
+	}
+
+	n := tx.len + len(values) - (end - start)
+	if len(values) > end-start && n > tx.c.cap {
+		return ErrOverflow
+	}
+
+	tx.ops = append(tx.ops, txOp[T]{
+		start:  start,
+		end:    end,
+		values: slices.Clone(values),
+	})
+	tx.len = n
+
+	return nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cursor
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Tx_Commit(t *testing.T) {
+	c := New([]int{1, 2, 3, 4, 5}, History[int](0))
+	tx := c.Begin()
+
+	// Each edit sees the buffer as left by the edits before it
+	steps := []func() error{
+		func() error { return tx.InsertAt(0, -1, -2) },
+		func() error { return tx.Set(2, 10) },
+		func() error { return tx.DeleteAt(6) },
+		func() error { return tx.Chop(3, 5) },
+		func() error { return tx.Replace(3, 40) },
+		func() error { return tx.Append(6) },
+	}
+
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("step %v: expected %v, got %v", i, nil, err)
+		}
+	}
+
+	if tx.Len() != 5 {
+		t.Fatalf("expected %v, got %v", 5, tx.Len())
+	}
+
+	// Nothing is applied before the commit
+	diff := cmp.Diff(items(c), []int{1, 2, 3, 4, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	err := tx.Commit()
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff = cmp.Diff(items(c), []int{-1, -2, 10, 40, 6})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	// The transaction is undone in one step
+	_ = c.Undo()
+
+	diff = cmp.Diff(items(c), []int{1, 2, 3, 4, 5})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if err = tx.Commit(); err != ErrTxDone {
+		t.Fatalf("expected %v, got %v", ErrTxDone, err)
+	}
+
+	if err = tx.Append(7); err != ErrTxDone {
+		t.Fatalf("expected %v, got %v", ErrTxDone, err)
+	}
+}
+
+func Test_Tx_Invalid(t *testing.T) {
+	tests := []struct {
+		edit func(tx *Tx[int]) error
+		err  error
+	}{
+		{func(tx *Tx[int]) error { return tx.Set(3, 0) }, ErrIndexOutOfRange},
+		{func(tx *Tx[int]) error { return tx.Set(-1, 0) }, ErrIndexOutOfRange},
+		{func(tx *Tx[int]) error { return tx.InsertAt(3, 0) }, ErrIndexOutOfRange},
+		{func(tx *Tx[int]) error { return tx.DeleteAt(3) }, ErrIndexOutOfRange},
+		{func(tx *Tx[int]) error { return tx.Chop(2, 1) }, ErrIndexOutOfRange},
+		{func(tx *Tx[int]) error { return tx.Chop(0, 3) }, ErrIndexOutOfRange},
+		{func(tx *Tx[int]) error { return tx.Replace(1, 0, 0, 0) }, ErrOverflow},
+		{func(tx *Tx[int]) error { return tx.Append(0, 0) }, ErrOverflow},
+		{func(tx *Tx[int]) error { return tx.InsertAt(0, 0, 0) }, ErrOverflow},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			c := New([]int{1, 2, 3}, Cap[int](4))
+			tx := c.Begin()
+
+			err := tt.edit(tx)
+			if err != tt.err {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+
+			if tx.Len() != 3 {
+				t.Fatalf("expected %v, got %v", 3, tx.Len())
+			}
+
+			// The failed edit is not buffered
+			err = tx.Commit()
+			if err != nil {
+				t.Fatalf("expected %v, got %v", nil, err)
+			}
+
+			diff := cmp.Diff(items(c), []int{1, 2, 3})
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func Test_Tx_Rollback(t *testing.T) {
+	c := New([]int{1, 2, 3})
+	tx := c.Begin()
+
+	values := []int{9, 9}
+	_ = tx.InsertAt(1, values...)
+	_ = tx.DeleteAt(0)
+
+	// The values are copied when the edit is made
+	values[0] = 0
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(items(c), []int{1, 2, 3})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if err := tx.Commit(); err != ErrTxDone {
+		t.Fatalf("expected %v, got %v", ErrTxDone, err)
+	}
+
+	tx = c.Begin()
+	_ = tx.InsertAt(1, values...)
+	_ = tx.Commit()
+
+	diff = cmp.Diff(items(c), []int{1, 0, 9, 2, 3})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Tx_Conflict(t *testing.T) {
+	c := New([]int{1, 2, 3, 4})
+	tx := c.Begin()
+
+	_ = tx.Set(0, 10)
+	_ = tx.Chop(2, 3)
+
+	// The cursor shrinks after the edits were checked, so the second no
+	// longer fits and neither is applied
+	c.DeleteAt(0)
+	c.DeleteAt(0)
+
+	err := tx.Commit()
+	if err != ErrIndexOutOfRange {
+		t.Fatalf("expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+
+	diff := cmp.Diff(items(c), []int{3, 4})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
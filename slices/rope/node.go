@@ -0,0 +1,271 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rope
+
+import (
+	"slices"
+)
+
+// maxLeaf is the largest number of elements held by a single leaf
+const maxLeaf = 512
+
+// node is either a leaf holding a run of elements or a branch joining two
+// non-empty subtrees. The empty rope is a nil node. Nodes belong to a
+// single rope, so leaves are edited in place while the shape of the tree
+// is changed by building new branches.
+type node[T any] struct {
+	left, right *node[T]
+	leaf        []T
+
+	size   int
+	height int
+}
+
+func (n *node[T]) len() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.size
+}
+
+// depth returns the height of the node, where a leaf has a height of 1
+// and the empty rope a height of 0
+func (n *node[T]) depth() int {
+	if n == nil {
+		return 0
+	}
+
+	return n.height
+}
+
+func (n *node[T]) isLeaf() bool {
+	return n.left == nil
+}
+
+// newLeaf returns a leaf over the values, taking ownership of them
+func newLeaf[T any](values []T) *node[T] {
+	if len(values) == 0 {
+		return nil
+	}
+
+	return &node[T]{leaf: values, size: len(values), height: 1}
+}
+
+// branch returns a new branch over two non-empty subtrees
+func branch[T any](left, right *node[T]) *node[T] {
+	return &node[T]{
+		left:   left,
+		right:  right,
+		size:   left.size + right.size,
+		height: max(left.height, right.height) + 1,
+	}
+}
+
+// build returns a balanced tree over a copy of the values
+func build[T any](values []T) *node[T] {
+	if len(values) <= maxLeaf {
+		return newLeaf(slices.Clone(values))
+	}
+
+	// Split on a leaf boundary so that every leaf but the last is full
+	leaves := (len(values) + maxLeaf - 1) / maxLeaf
+	mid := leaves / 2 * maxLeaf
+
+	return branch(build(values[:mid]), build(values[mid:]))
+}
+
+func (n *node[T]) at(i int) T {
+	for !n.isLeaf() {
+		if i < n.left.size {
+			n = n.left
+		} else {
+			i -= n.left.size
+			n = n.right
+		}
+	}
+
+	return n.leaf[i]
+}
+
+func (n *node[T]) set(i int, v T) {
+	for !n.isLeaf() {
+		if i < n.left.size {
+			n = n.left
+		} else {
+			i -= n.left.size
+			n = n.right
+		}
+	}
+
+	n.leaf[i] = v
+}
+
+// appendTo appends the elements from start to end to out
+func (n *node[T]) appendTo(out []T, start, end int) []T {
+	if n == nil || start >= end {
+		return out
+	}
+
+	if n.isLeaf() {
+		return append(out, n.leaf[start:end]...)
+	}
+
+	if start < n.left.size {
+		out = n.left.appendTo(out, start, min(end, n.left.size))
+	}
+
+	if end > n.left.size {
+		out = n.right.appendTo(out, max(start-n.left.size, 0), end-n.left.size)
+	}
+
+	return out
+}
+
+// leaves calls fn with the elements of every leaf in order until it
+// returns false
+func (n *node[T]) leaves(fn func([]T) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	if n.isLeaf() {
+		return fn(n.leaf)
+	}
+
+	return n.left.leaves(fn) && n.right.leaves(fn)
+}
+
+// join returns a balanced tree holding the elements of l followed by
+// those of r
+func join[T any](l, r *node[T]) *node[T] {
+	switch {
+	case l == nil:
+		return r
+	case r == nil:
+		return l
+	case l.isLeaf() && r.isLeaf() && l.size+r.size <= maxLeaf:
+		// Merge small leaves so that repeated edits do not fragment them
+		leaf := make([]T, 0, l.size+r.size)
+		leaf = append(leaf, l.leaf...)
+		return newLeaf(append(leaf, r.leaf...))
+	case l.height > r.height+1:
+		return balance(l.left, join(l.right, r))
+	case r.height > l.height+1:
+		return balance(join(l, r.left), r.right)
+	default:
+		return branch(l, r)
+	}
+}
+
+// balance returns a branch over the subtrees, rotating it if their heights
+// differ by more than one
+func balance[T any](left, right *node[T]) *node[T] {
+	switch {
+	case left.height > right.height+1:
+		if left.left.depth() < left.right.depth() {
+			left = rotateLeft(left)
+		}
+
+		return branch(left.left, branch(left.right, right))
+	case right.height > left.height+1:
+		if right.right.depth() < right.left.depth() {
+			right = rotateRight(right)
+		}
+
+		return branch(branch(left, right.left), right.right)
+	default:
+		return branch(left, right)
+	}
+}
+
+func rotateLeft[T any](n *node[T]) *node[T] {
+	return branch(branch(n.left, n.right.left), n.right.right)
+}
+
+func rotateRight[T any](n *node[T]) *node[T] {
+	return branch(n.left.left, branch(n.left.right, n.right))
+}
+
+// split divides the tree into the first i elements and the rest
+func split[T any](n *node[T], i int) (*node[T], *node[T]) {
+	switch {
+	case n == nil:
+		return nil, nil
+	case i <= 0:
+		return nil, n
+	case i >= n.size:
+		return n, nil
+	case n.isLeaf():
+		// The halves share the leaf, with the capacity of the first
+		// clipped so that growing it in place cannot reach the second
+		return newLeaf(n.leaf[:i:i]), newLeaf(n.leaf[i:])
+	case i < n.left.size:
+		l, r := split(n.left, i)
+		return l, join(r, n.right)
+	default:
+		l, r := split(n.right, i-n.left.size)
+		return join(n.left, l), r
+	}
+}
+
+// splice replaces the elements from start to end with copies of the
+// values, returning the new root
+func splice[T any](n *node[T], start, end int, values []T) *node[T] {
+	if spliceLeaf(n, start, end, values) {
+		return n
+	}
+
+	l, rest := split(n, start)
+	_, r := split(rest, end-start)
+
+	return join(join(l, build(values)), r)
+}
+
+// spliceLeaf edits a single leaf in place when it holds the whole range
+// from start to end and stays within the leaf size, updating the sizes of
+// its parents. It reports whether the edit was made.
+func spliceLeaf[T any](n *node[T], start, end int, values []T) bool {
+	if n == nil {
+		return false
+	}
+
+	delta := len(values) - (end - start)
+
+	if n.isLeaf() {
+		size := n.size + delta
+		if size <= 0 || size > maxLeaf {
+			return false
+		}
+
+		n.leaf = slices.Replace(n.leaf, start, end, values...)
+		n.size = size
+		return true
+	}
+
+	var ok bool
+	switch {
+	case end <= n.left.size && start < n.left.size:
+		ok = spliceLeaf(n.left, start, end, values)
+	case start >= n.left.size:
+		ok = spliceLeaf(n.right, start-n.left.size, end-n.left.size, values)
+	}
+
+	if ok {
+		n.size += delta
+	}
+
+	return ok
+}
@@ -0,0 +1,151 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rope provides a generic rope, a balanced tree of element runs
+// which supports indexing, splitting, concatenation, insertion and
+// deletion in O(log n) time, for very large sequences such as the text of
+// huge files.
+package rope
+
+import (
+	"errors"
+	"iter"
+)
+
+var ErrIndexOutOfRange = errors.New("index out of range")
+
+// Rope is a sequence of elements held in an AVL balanced tree whose leaves
+// hold runs of up to 512 elements. Edits within a leaf are made in place
+// while larger edits split and rejoin the tree, so the cost of every
+// operation grows with the log of the length rather than the length.
+//
+// The zero value is an empty rope ready to use. A Rope is not safe for
+// concurrent use.
+type Rope[T any] struct {
+	root *node[T]
+}
+
+// New creates a new rope over a copy of the data
+func New[T any](data []T) *Rope[T] {
+	return &Rope[T]{root: build(data)}
+}
+
+// Len returns the number of elements in the rope
+func (r *Rope[T]) Len() int {
+	return r.root.len()
+}
+
+// At returns the element at index i
+func (r *Rope[T]) At(i int) (T, error) {
+	if i < 0 || i >= r.root.len() {
+		var out T
+		return out, ErrIndexOutOfRange
+	}
+
+	return r.root.at(i), nil
+}
+
+// Set replaces the element at index i
+func (r *Rope[T]) Set(i int, v T) error {
+	if i < 0 || i >= r.root.len() {
+		return ErrIndexOutOfRange
+	}
+
+	r.root.set(i, v)
+	return nil
+}
+
+// Slice returns a copy of the elements from start to end, where end may
+// be the length of the rope
+func (r *Rope[T]) Slice(start, end int) ([]T, error) {
+	if start < 0 || end > r.root.len() || start > end {
+		return nil, ErrIndexOutOfRange
+	}
+
+	return r.root.appendTo(make([]T, 0, end-start), start, end), nil
+}
+
+// Values returns a copy of every element of the rope
+func (r *Rope[T]) Values() []T {
+	return r.root.appendTo(make([]T, 0, r.root.len()), 0, r.root.len())
+}
+
+// All returns an iterator over the index and element of every element of
+// the rope. The rope must not be edited during the iteration.
+func (r *Rope[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		r.root.leaves(func(leaf []T) bool {
+			for _, v := range leaf {
+				if !yield(i, v) {
+					return false
+				}
+
+				i++
+			}
+
+			return true
+		})
+	}
+}
+
+// Insert inserts copies of the values before index i, where i may be the
+// length of the rope to append them
+func (r *Rope[T]) Insert(i int, values ...T) error {
+	if i < 0 || i > r.root.len() {
+		return ErrIndexOutOfRange
+	}
+
+	r.root = splice(r.root, i, i, values)
+	return nil
+}
+
+// Append adds copies of the values to the end of the rope
+func (r *Rope[T]) Append(values ...T) {
+	r.root = splice(r.root, r.root.len(), r.root.len(), values)
+}
+
+// Delete removes the elements from start to end
+func (r *Rope[T]) Delete(start, end int) error {
+	if start < 0 || end > r.root.len() || start > end {
+		return ErrIndexOutOfRange
+	}
+
+	r.root = splice(r.root, start, end, nil)
+	return nil
+}
+
+// Split splits the rope at index i, leaving the first i elements in the
+// rope and returning a new rope holding the rest
+func (r *Rope[T]) Split(i int) (*Rope[T], error) {
+	if i < 0 || i > r.root.len() {
+		return nil, ErrIndexOutOfRange
+	}
+
+	var rest *node[T]
+	r.root, rest = split(r.root, i)
+
+	return &Rope[T]{root: rest}, nil
+}
+
+// Concat moves the elements of other to the end of the rope, leaving
+// other empty
+func (r *Rope[T]) Concat(other *Rope[T]) {
+	if other == r {
+		other = &Rope[T]{root: build(r.Values())}
+	}
+
+	r.root = join(r.root, other.root)
+	other.root = nil
+}
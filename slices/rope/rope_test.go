@@ -0,0 +1,313 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rope
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"go.devnw.com/ds/slices/cursor"
+	"go.devnw.com/ds/slices/cursor/cursortest"
+)
+
+func seq(n int) []int {
+	out := make([]int, n)
+	for i := range out {
+		out[i] = i
+	}
+
+	return out
+}
+
+// checkTree verifies the sizes, heights and balance of every node
+func checkTree[T any](t *testing.T, n *node[T]) {
+	t.Helper()
+
+	if n == nil {
+		return
+	}
+
+	if n.isLeaf() {
+		if n.size != len(n.leaf) || n.size == 0 || n.size > maxLeaf || n.height != 1 {
+			t.Fatalf("invalid leaf: size %v, len %v, height %v", n.size, len(n.leaf), n.height)
+		}
+
+		return
+	}
+
+	checkTree(t, n.left)
+	checkTree(t, n.right)
+
+	if n.size != n.left.size+n.right.size {
+		t.Fatalf("expected size %v, got %v", n.left.size+n.right.size, n.size)
+	}
+
+	if n.height != max(n.left.height, n.right.height)+1 {
+		t.Fatalf("expected height %v, got %v", max(n.left.height, n.right.height)+1, n.height)
+	}
+
+	if diff := n.left.height - n.right.height; diff < -1 || diff > 1 {
+		t.Fatalf("unbalanced node: heights %v and %v", n.left.height, n.right.height)
+	}
+}
+
+func Test_Rope_Access(t *testing.T) {
+	r := New(seq(5000))
+	checkTree(t, r.root)
+
+	if r.Len() != 5000 {
+		t.Fatalf("expected %v, got %v", 5000, r.Len())
+	}
+
+	for _, i := range []int{0, 511, 512, 2500, 4999} {
+		v, err := r.At(i)
+		if err != nil || v != i {
+			t.Fatalf("expected %v, got %v (%v)", i, v, err)
+		}
+	}
+
+	if _, err := r.At(5000); err != ErrIndexOutOfRange {
+		t.Fatalf("expected %v, got %v", ErrIndexOutOfRange, err)
+	}
+
+	if err := r.Set(1000, -1); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	got, err := r.Slice(998, 1002)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(got, []int{998, 999, -1, 1001})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	count := 0
+	for i, v := range r.All() {
+		if i != 1000 && v != i {
+			t.Fatalf("expected %v, got %v", i, v)
+		}
+
+		count++
+	}
+
+	if count != 5000 {
+		t.Fatalf("expected %v, got %v", 5000, count)
+	}
+}
+
+func Test_Rope_Edit(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(r *Rope[int]) error
+		want []int
+	}{
+		{
+			"insert",
+			func(r *Rope[int]) error { return r.Insert(2, -1, -2) },
+			[]int{0, 1, -1, -2, 2, 3, 4},
+		},
+		{
+			"insert at end",
+			func(r *Rope[int]) error { return r.Insert(5, -1) },
+			[]int{0, 1, 2, 3, 4, -1},
+		},
+		{
+			"insert out of range",
+			func(r *Rope[int]) error { return r.Insert(6, -1) },
+			[]int{0, 1, 2, 3, 4},
+		},
+		{
+			"delete",
+			func(r *Rope[int]) error { return r.Delete(1, 4) },
+			[]int{0, 4},
+		},
+		{
+			"delete all",
+			func(r *Rope[int]) error { return r.Delete(0, 5) },
+			[]int{},
+		},
+		{
+			"delete out of range",
+			func(r *Rope[int]) error { return r.Delete(3, 6) },
+			[]int{0, 1, 2, 3, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(seq(5))
+			_ = tt.edit(r)
+
+			diff := cmp.Diff(r.Values(), tt.want)
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+		})
+	}
+}
+
+func Test_Rope_SplitConcat(t *testing.T) {
+	for _, i := range []int{0, 1, 511, 512, 1000, 2999, 3000} {
+		t.Run(fmt.Sprintf("test_%v", i), func(t *testing.T) {
+			r := New(seq(3000))
+
+			rest, err := r.Split(i)
+			if err != nil {
+				t.Fatalf("expected %v, got %v", nil, err)
+			}
+
+			checkTree(t, r.root)
+			checkTree(t, rest.root)
+
+			diff := cmp.Diff(r.Values(), seq(i))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			if rest.Len() != 3000-i {
+				t.Fatalf("expected %v, got %v", 3000-i, rest.Len())
+			}
+
+			// Editing one half must not affect the other
+			r.Append(-1)
+			if rest.Len() > 0 {
+				v, _ := rest.At(0)
+				if v != i {
+					t.Fatalf("expected %v, got %v", i, v)
+				}
+			}
+
+			_ = r.Delete(r.Len()-1, r.Len())
+			r.Concat(rest)
+			checkTree(t, r.root)
+
+			diff = cmp.Diff(r.Values(), seq(3000))
+			if diff != "" {
+				t.Fatalf(diff)
+			}
+
+			if rest.Len() != 0 {
+				t.Fatalf("expected %v, got %v", 0, rest.Len())
+			}
+		})
+	}
+
+	r := New(seq(3))
+	r.Concat(r)
+
+	diff := cmp.Diff(r.Values(), []int{0, 1, 2, 0, 1, 2})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+}
+
+func Test_Rope_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	var r Rope[int]
+	var want []int
+
+	for i := range 3000 {
+		start := rng.Intn(len(want) + 1)
+		end := start + rng.Intn(min(len(want)-start, 2000)+1)
+
+		switch rng.Intn(4) {
+		case 0:
+			_ = r.Delete(start, end)
+			want = slices.Delete(want, start, end)
+		case 1:
+			rest, _ := r.Split(start)
+			r.Concat(rest)
+		default:
+			values := seq(rng.Intn(1200))
+			_ = r.Insert(start, values...)
+			want = slices.Insert(want, start, values...)
+		}
+
+		if i%100 == 0 {
+			checkTree(t, r.root)
+
+			diff := cmp.Diff(r.Values(), want, cmpEmpty)
+			if diff != "" {
+				t.Fatalf("edit %v: %v", i, diff)
+			}
+		}
+	}
+}
+
+// cmpEmpty treats nil and empty slices as equal
+var cmpEmpty = cmp.Comparer(func(a, b []int) bool {
+	return slices.Equal(a, b)
+})
+
+func Test_Storage_Conformance(t *testing.T) {
+	cursortest.TestStorage(t, Storage[int])
+}
+
+func Test_Storage_Cursor(t *testing.T) {
+	c := cursor.New(seq(10_000), cursor.WithStorage(Storage[int]))
+
+	if _, err := c.Seek(5000); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	values, rem, err := c.Take(3)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	diff := cmp.Diff(values, []int{5000, 5001, 5002})
+	if diff != "" {
+		t.Fatalf(diff)
+	}
+
+	if rem.Len() != 10_000-5003 {
+		t.Fatalf("expected %v, got %v", 10_000-5003, rem.Len())
+	}
+
+	for range 100 {
+		_ = c.Prepend(-1)
+	}
+
+	v, err := c.Get()
+	if err != nil || v != 5000 {
+		t.Fatalf("expected %v, got %v (%v)", 5000, v, err)
+	}
+}
+
+func Benchmark_Rope_Insert(b *testing.B) {
+	backends := map[string]func([]int) cursor.Storage[int]{
+		"slice": cursor.SliceStorage[int],
+		"rope":  Storage[int],
+	}
+
+	for name, fn := range backends {
+		b.Run(name, func(b *testing.B) {
+			c := cursor.New(seq(1_000_000), cursor.WithStorage(fn))
+			rng := rand.New(rand.NewSource(1))
+
+			b.ResetTimer()
+			for range b.N {
+				_ = c.InsertAt(rng.Intn(c.Len()), 1)
+			}
+		})
+	}
+}
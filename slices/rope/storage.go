@@ -0,0 +1,53 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rope
+
+import (
+	"go.devnw.com/ds/slices/cursor"
+)
+
+// storage adapts a rope to the storage interface of a cursor, which
+// checks the bounds of every call before it is made
+type storage[T any] struct {
+	root *node[T]
+}
+
+// Storage returns cursor storage held in a rope, so that existing cursor
+// code runs unchanged over very large buffers with O(log n) edits:
+//
+//	c := cursor.New(lines, cursor.WithStorage(rope.Storage[string]))
+func Storage[T any](data []T) cursor.Storage[T] {
+	return &storage[T]{root: build(data)}
+}
+
+func (s *storage[T]) Len() int {
+	return s.root.len()
+}
+
+func (s *storage[T]) At(i int) T {
+	return s.root.at(i)
+}
+
+func (s *storage[T]) Set(i int, v T) {
+	s.root.set(i, v)
+}
+
+func (s *storage[T]) Slice(start, end int) []T {
+	return s.root.appendTo(make([]T, 0, end-start), start, end)
+}
+
+func (s *storage[T]) Splice(start, end int, values []T) {
+	s.root = splice(s.root, start, end, values)
+}
@@ -17,3 +17,8 @@ package trees
 import "errors"
 
 var ErrNilRoot = errors.New("root is nil")
+var ErrNilNode = errors.New("node is nil")
+var ErrCycle = errors.New("node is an ancestor of the parent")
+var ErrNotChild = errors.New("node is not a child of the parent")
+var ErrNoParent = errors.New("node has no parent")
+var ErrIndexOutOfRange = errors.New("index out of range")
@@ -64,16 +64,163 @@ func (n *Node[T]) Children() []*Node[T] {
 	return n.children
 }
 
-// AddChild adds a child to the node.
-func (n *Node[T]) AddChildren(c ...*Node[T]) {
+// AddChildren adds the children to the end of the node's children. A child
+// which already belongs to another parent is detached from it first. If any
+// of the children is the node itself or one of its ancestors then
+// trees.ErrCycle is returned and the tree is left unchanged.
+//
+// Checking for a cycle walks the ancestors of the node for every child
+// which has children of its own, so adding a subtree costs O(depth) of the
+// node while adding a leaf costs O(1).
+func (n *Node[T]) AddChildren(c ...*Node[T]) error {
+	for _, child := range c {
+		if child != nil && child.isAncestorOf(n) {
+			return trees.ErrCycle
+		}
+	}
+
 	for _, child := range c {
 		if child == nil {
 			continue
 		}
 
+		child.Detach()
 		child.parent = n
 		n.children = append(n.children, child)
 	}
+
+	return nil
+}
+
+// InsertChildAt inserts the child at the given index of the node's
+// children, detaching it from any previous parent. The index is relative
+// to the children of the node once the child has been detached. Like
+// AddChildren, inserting a child with children of its own walks the
+// ancestors of the node to check for a cycle.
+func (n *Node[T]) InsertChildAt(index int, c *Node[T]) error {
+	if c == nil {
+		return trees.ErrNilNode
+	}
+
+	if c.isAncestorOf(n) {
+		return trees.ErrCycle
+	}
+
+	size := len(n.children)
+	if c.parent == n {
+		size--
+	}
+
+	if index < 0 || index > size {
+		return trees.ErrIndexOutOfRange
+	}
+
+	c.Detach()
+	c.parent = n
+
+	n.children = append(n.children, nil)
+	copy(n.children[index+1:], n.children[index:])
+	n.children[index] = c
+
+	return nil
+}
+
+// RemoveChild removes the child from the node, leaving the child as the
+// root of its own subtree.
+func (n *Node[T]) RemoveChild(c *Node[T]) error {
+	if c == nil {
+		return trees.ErrNilNode
+	}
+
+	if c.parent != n {
+		return trees.ErrNotChild
+	}
+
+	c.Detach()
+	return nil
+}
+
+// Detach removes the node from its parent, leaving it as the root of its
+// own subtree. Detaching a node without a parent is a no-op.
+func (n *Node[T]) Detach() {
+	if n.parent == nil {
+		return
+	}
+
+	siblings := n.parent.children
+	for i, s := range siblings {
+		if s == n {
+			copy(siblings[i:], siblings[i+1:])
+			siblings[len(siblings)-1] = nil
+			n.parent.children = siblings[:len(siblings)-1]
+			break
+		}
+	}
+
+	n.parent = nil
+}
+
+// MoveTo moves the node, along with its subtree, to the given index of
+// the new parent's children.
+func (n *Node[T]) MoveTo(parent *Node[T], index int) error {
+	if parent == nil {
+		return trees.ErrNilNode
+	}
+
+	return parent.InsertChildAt(index, n)
+}
+
+// ReplaceWith replaces the node in its parent's children with the given
+// node, which is detached from any previous parent. The replaced node is
+// left as the root of its own subtree.
+func (n *Node[T]) ReplaceWith(r *Node[T]) error {
+	if r == nil {
+		return trees.ErrNilNode
+	}
+
+	if n.parent == nil {
+		return trees.ErrNoParent
+	}
+
+	if r == n {
+		return nil
+	}
+
+	if r.isAncestorOf(n.parent) {
+		return trees.ErrCycle
+	}
+
+	r.Detach()
+
+	parent := n.parent
+	for i, s := range parent.children {
+		if s == n {
+			parent.children[i] = r
+			break
+		}
+	}
+
+	r.parent = parent
+	n.parent = nil
+
+	return nil
+}
+
+// isAncestorOf reports whether the node is the given node or one of its
+// ancestors. A node without children can only be an ancestor of itself, so
+// the ancestors of o are only walked when n has children.
+func (n *Node[T]) isAncestorOf(o *Node[T]) bool {
+	if len(n.children) == 0 {
+		return n == o
+	}
+
+	for p := o; p != nil; p = p.parent {
+		if p == n {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Root returns the root of the tree.
@@ -84,18 +231,11 @@ func (t *Tree[T]) Root() *Node[T] {
 // Leaves returns the leaves of the tree.
 func (t *Tree[T]) Leaves() []*Node[T] {
 	var leaves []*Node[T]
-	t.root.leaves(&leaves)
-	return leaves
-}
-
-// leaves appends the leaves of the node to the given slice.
-func (n *Node[T]) leaves(leaves *[]*Node[T]) {
-	if len(n.children) == 0 {
-		*leaves = append(*leaves, n)
-		return
+	for n := range t.root.PreOrder() {
+		if len(n.children) == 0 {
+			leaves = append(leaves, n)
+		}
 	}
 
-	for _, c := range n.children {
-		c.leaves(leaves)
-	}
+	return leaves
 }
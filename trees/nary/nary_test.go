@@ -174,3 +174,266 @@ func Test_NewFrom(t *testing.T) {
 		})
 	}
 }
+
+// childValues returns the values of the node's children and fails the test
+// if any child does not point back at the node.
+func childValues(t *testing.T, n *Node[int]) []int {
+	t.Helper()
+
+	var out []int
+	for _, c := range n.Children() {
+		if c.Parent() != n {
+			t.Fatalf("expected parent %v, got %v", n.Value(), c.Parent())
+		}
+
+		out = append(out, c.Value())
+	}
+
+	return out
+}
+
+func Test_Node_AddChildren_Reparent(t *testing.T) {
+	a, b := &Node[int]{value: 1}, &Node[int]{value: 2}
+	c := &Node[int]{value: 3}
+
+	if err := a.AddChildren(c); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if err := b.AddChildren(c); err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if got := childValues(t, a); len(got) != 0 {
+		t.Fatalf("expected no children, got %v", got)
+	}
+
+	if got := childValues(t, b); !reflect.DeepEqual(got, []int{3}) {
+		t.Fatalf("expected %v, got %v", []int{3}, got)
+	}
+}
+
+func Test_Node_AddChildren_Cycle(t *testing.T) {
+	tree := sample()
+	n5 := tree.root.children[0].children[0]
+
+	tests := map[string]*Node[int]{
+		"self":        n5,
+		"parent":      n5.parent,
+		"grandparent": tree.root,
+	}
+
+	for name, child := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := n5.AddChildren(&Node[int]{value: 10}, child)
+			if err != trees.ErrCycle {
+				t.Fatalf("expected %v, got %v", trees.ErrCycle, err)
+			}
+
+			if len(n5.children) != 0 {
+				t.Fatalf("expected no children, got %v", len(n5.children))
+			}
+		})
+	}
+}
+
+func Test_Node_InsertChildAt(t *testing.T) {
+	tests := map[string]struct {
+		index int
+		node  func(tree *Tree[int]) *Node[int]
+		want  []int
+		err   error
+	}{
+		"front":     {0, func(*Tree[int]) *Node[int] { return &Node[int]{value: 10} }, []int{10, 2, 3, 4}, nil},
+		"middle":    {1, func(*Tree[int]) *Node[int] { return &Node[int]{value: 10} }, []int{2, 10, 3, 4}, nil},
+		"end":       {3, func(*Tree[int]) *Node[int] { return &Node[int]{value: 10} }, []int{2, 3, 4, 10}, nil},
+		"too-large": {4, func(*Tree[int]) *Node[int] { return &Node[int]{value: 10} }, []int{2, 3, 4}, trees.ErrIndexOutOfRange},
+		"negative":  {-1, func(*Tree[int]) *Node[int] { return &Node[int]{value: 10} }, []int{2, 3, 4}, trees.ErrIndexOutOfRange},
+		"nil":       {0, func(*Tree[int]) *Node[int] { return nil }, []int{2, 3, 4}, trees.ErrNilNode},
+		"sibling":   {0, func(t *Tree[int]) *Node[int] { return t.root.children[2] }, []int{4, 2, 3}, nil},
+		"sibling-end": {2, func(t *Tree[int]) *Node[int] { return t.root.children[0] },
+			[]int{3, 4, 2}, nil},
+		"sibling-too-large": {3, func(t *Tree[int]) *Node[int] { return t.root.children[0] },
+			[]int{2, 3, 4}, trees.ErrIndexOutOfRange},
+		"grandchild": {1, func(t *Tree[int]) *Node[int] { return t.root.children[2].children[0] },
+			[]int{2, 7, 3, 4}, nil},
+		"cycle": {0, func(t *Tree[int]) *Node[int] { return t.root }, []int{2, 3, 4}, trees.ErrCycle},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tree := sample()
+
+			err := tree.root.InsertChildAt(tc.index, tc.node(tree))
+			if err != tc.err {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+
+			got := childValues(t, tree.root)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_Node_RemoveChild(t *testing.T) {
+	tree := sample()
+	n2 := tree.root.children[0]
+
+	err := tree.root.RemoveChild(n2.children[0])
+	if err != trees.ErrNotChild {
+		t.Fatalf("expected %v, got %v", trees.ErrNotChild, err)
+	}
+
+	err = tree.root.RemoveChild(nil)
+	if err != trees.ErrNilNode {
+		t.Fatalf("expected %v, got %v", trees.ErrNilNode, err)
+	}
+
+	err = tree.root.RemoveChild(n2)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	if n2.Parent() != nil {
+		t.Fatalf("expected %v, got %v", nil, n2.Parent())
+	}
+
+	got := childValues(t, tree.root)
+	if !reflect.DeepEqual(got, []int{3, 4}) {
+		t.Fatalf("expected %v, got %v", []int{3, 4}, got)
+	}
+
+	got = childValues(t, n2)
+	if !reflect.DeepEqual(got, []int{5, 6}) {
+		t.Fatalf("expected %v, got %v", []int{5, 6}, got)
+	}
+}
+
+func Test_Node_Detach(t *testing.T) {
+	tree := sample()
+	n4 := tree.root.children[2]
+
+	n4.Detach()
+	n4.Detach()
+	tree.root.Detach()
+
+	if n4.Parent() != nil {
+		t.Fatalf("expected %v, got %v", nil, n4.Parent())
+	}
+
+	got := childValues(t, tree.root)
+	if !reflect.DeepEqual(got, []int{2, 3}) {
+		t.Fatalf("expected %v, got %v", []int{2, 3}, got)
+	}
+
+	leaves := values(tree.root.PreOrder(), -1)
+	if !reflect.DeepEqual(leaves, []int{1, 2, 5, 6, 3}) {
+		t.Fatalf("expected %v, got %v", []int{1, 2, 5, 6, 3}, leaves)
+	}
+}
+
+func Test_Node_MoveTo(t *testing.T) {
+	tree := sample()
+	n2 := tree.root.children[0]
+	n4 := tree.root.children[2]
+
+	err := n2.MoveTo(n4, 0)
+	if err != nil {
+		t.Fatalf("expected %v, got %v", nil, err)
+	}
+
+	got := values(tree.PreOrder(), -1)
+	if !reflect.DeepEqual(got, []int{1, 3, 4, 2, 5, 6, 7}) {
+		t.Fatalf("expected %v, got %v", []int{1, 3, 4, 2, 5, 6, 7}, got)
+	}
+
+	err = n4.MoveTo(n2.children[0], 0)
+	if err != trees.ErrCycle {
+		t.Fatalf("expected %v, got %v", trees.ErrCycle, err)
+	}
+
+	err = n4.MoveTo(nil, 0)
+	if err != trees.ErrNilNode {
+		t.Fatalf("expected %v, got %v", trees.ErrNilNode, err)
+	}
+
+	got = values(tree.PreOrder(), -1)
+	if !reflect.DeepEqual(got, []int{1, 3, 4, 2, 5, 6, 7}) {
+		t.Fatalf("expected %v, got %v", []int{1, 3, 4, 2, 5, 6, 7}, got)
+	}
+}
+
+func Test_Node_ReplaceWith(t *testing.T) {
+	tests := map[string]struct {
+		target func(tree *Tree[int]) *Node[int]
+		with   func(tree *Tree[int]) *Node[int]
+		want   []int
+		err    error
+	}{
+		"new": {
+			func(t *Tree[int]) *Node[int] { return t.root.children[0] },
+			func(*Tree[int]) *Node[int] { return &Node[int]{value: 10} },
+			[]int{1, 10, 3, 4, 7},
+			nil,
+		},
+		"descendant": {
+			func(t *Tree[int]) *Node[int] { return t.root.children[0] },
+			func(t *Tree[int]) *Node[int] { return t.root.children[0].children[1] },
+			[]int{1, 6, 3, 4, 7},
+			nil,
+		},
+		"sibling": {
+			func(t *Tree[int]) *Node[int] { return t.root.children[0] },
+			func(t *Tree[int]) *Node[int] { return t.root.children[2] },
+			[]int{1, 4, 7, 3},
+			nil,
+		},
+		"self": {
+			func(t *Tree[int]) *Node[int] { return t.root.children[0] },
+			func(t *Tree[int]) *Node[int] { return t.root.children[0] },
+			[]int{1, 2, 5, 6, 3, 4, 7},
+			nil,
+		},
+		"root": {
+			func(t *Tree[int]) *Node[int] { return t.root },
+			func(*Tree[int]) *Node[int] { return &Node[int]{value: 10} },
+			[]int{1, 2, 5, 6, 3, 4, 7},
+			trees.ErrNoParent,
+		},
+		"ancestor": {
+			func(t *Tree[int]) *Node[int] { return t.root.children[0].children[0] },
+			func(t *Tree[int]) *Node[int] { return t.root },
+			[]int{1, 2, 5, 6, 3, 4, 7},
+			trees.ErrCycle,
+		},
+		"nil": {
+			func(t *Tree[int]) *Node[int] { return t.root.children[0] },
+			func(*Tree[int]) *Node[int] { return nil },
+			[]int{1, 2, 5, 6, 3, 4, 7},
+			trees.ErrNilNode,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			tree := sample()
+			target := tc.target(tree)
+
+			err := target.ReplaceWith(tc.with(tree))
+			if err != tc.err {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+
+			got := values(tree.PreOrder(), -1)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+
+			for n := range tree.PreOrder() {
+				childValues(t, n)
+			}
+		})
+	}
+}
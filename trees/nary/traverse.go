@@ -0,0 +1,126 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nary
+
+import (
+	"iter"
+)
+
+// PreOrder returns an iterator over the nodes of the tree in pre-order.
+func (t *Tree[T]) PreOrder() iter.Seq[*Node[T]] {
+	return t.root.PreOrder()
+}
+
+// PostOrder returns an iterator over the nodes of the tree in post-order.
+func (t *Tree[T]) PostOrder() iter.Seq[*Node[T]] {
+	return t.root.PostOrder()
+}
+
+// BreadthFirst returns an iterator over the nodes of the tree in
+// level-order.
+func (t *Tree[T]) BreadthFirst() iter.Seq[*Node[T]] {
+	return t.root.BreadthFirst()
+}
+
+// DepthFirst returns an iterator over the nodes of the tree depth first.
+func (t *Tree[T]) DepthFirst() iter.Seq[*Node[T]] {
+	return t.root.DepthFirst()
+}
+
+// PreOrder returns an iterator over the node and its descendants, visiting
+// each node before its children.
+func (n *Node[T]) PreOrder() iter.Seq[*Node[T]] {
+	return func(yield func(*Node[T]) bool) {
+		if n == nil {
+			return
+		}
+
+		stack := []*Node[T]{n}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			if !yield(node) {
+				return
+			}
+
+			// Push the children in reverse so the first child is
+			// visited first
+			for i := len(node.children) - 1; i >= 0; i-- {
+				stack = append(stack, node.children[i])
+			}
+		}
+	}
+}
+
+// PostOrder returns an iterator over the node and its descendants, visiting
+// each node after its children.
+func (n *Node[T]) PostOrder() iter.Seq[*Node[T]] {
+	type frame struct {
+		node *Node[T]
+		next int
+	}
+
+	return func(yield func(*Node[T]) bool) {
+		if n == nil {
+			return
+		}
+
+		stack := []frame{{node: n}}
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+
+			if top.next < len(top.node.children) {
+				child := top.node.children[top.next]
+				top.next++
+				stack = append(stack, frame{node: child})
+				continue
+			}
+
+			stack = stack[:len(stack)-1]
+			if !yield(top.node) {
+				return
+			}
+		}
+	}
+}
+
+// BreadthFirst returns an iterator over the node and its descendants one
+// level at a time.
+func (n *Node[T]) BreadthFirst() iter.Seq[*Node[T]] {
+	return func(yield func(*Node[T]) bool) {
+		if n == nil {
+			return
+		}
+
+		queue := []*Node[T]{n}
+		for len(queue) > 0 {
+			node := queue[0]
+			queue = queue[1:]
+
+			if !yield(node) {
+				return
+			}
+
+			queue = append(queue, node.children...)
+		}
+	}
+}
+
+// DepthFirst returns an iterator over the node and its descendants depth
+// first. It is equivalent to PreOrder.
+func (n *Node[T]) DepthFirst() iter.Seq[*Node[T]] {
+	return n.PreOrder()
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 Developer Network
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nary
+
+import (
+	"iter"
+	"reflect"
+	"testing"
+)
+
+// sample builds the tree
+//
+//	    1
+//	  / | \
+//	 2  3  4
+//	/ \    |
+//	5  6   7
+func sample() *Tree[int] {
+	tree := New(1)
+
+	n2 := &Node[int]{value: 2}
+	n2.AddChildren(&Node[int]{value: 5}, &Node[int]{value: 6})
+
+	n4 := &Node[int]{value: 4}
+	n4.AddChildren(&Node[int]{value: 7})
+
+	tree.root.AddChildren(n2, &Node[int]{value: 3}, n4)
+
+	return tree
+}
+
+func values(seq iter.Seq[*Node[int]], limit int) []int {
+	var out []int
+	for n := range seq {
+		out = append(out, n.Value())
+		if len(out) == limit {
+			break
+		}
+	}
+
+	return out
+}
+
+func Test_Tree_Traversals(t *testing.T) {
+	tree := sample()
+
+	tests := map[string]struct {
+		seq   iter.Seq[*Node[int]]
+		limit int
+		want  []int
+	}{
+		"pre-order":           {tree.PreOrder(), -1, []int{1, 2, 5, 6, 3, 4, 7}},
+		"pre-order-limit":     {tree.PreOrder(), 3, []int{1, 2, 5}},
+		"post-order":          {tree.PostOrder(), -1, []int{5, 6, 2, 3, 7, 4, 1}},
+		"post-order-limit":    {tree.PostOrder(), 4, []int{5, 6, 2, 3}},
+		"breadth-first":       {tree.BreadthFirst(), -1, []int{1, 2, 3, 4, 5, 6, 7}},
+		"breadth-first-limit": {tree.BreadthFirst(), 2, []int{1, 2}},
+		"depth-first":         {tree.DepthFirst(), -1, []int{1, 2, 5, 6, 3, 4, 7}},
+		"subtree-pre-order":   {tree.root.children[0].PreOrder(), -1, []int{2, 5, 6}},
+		"subtree-post-order":  {tree.root.children[2].PostOrder(), -1, []int{7, 4}},
+		"nil-tree":            {(&Tree[int]{}).PreOrder(), -1, nil},
+		"nil-node":            {(*Node[int])(nil).BreadthFirst(), -1, nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := values(tc.seq, tc.limit)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func Test_Tree_Leaves_Deep(t *testing.T) {
+	const depth = 1_000_000
+
+	tree := New(0)
+	n := tree.root
+	for i := 1; i < depth; i++ {
+		child := &Node[int]{value: i}
+		n.AddChildren(child)
+		n = child
+	}
+
+	leaves := tree.Leaves()
+	if len(leaves) != 1 {
+		t.Fatalf("expected %v, got %v", 1, len(leaves))
+	}
+
+	if leaves[0].Value() != depth-1 {
+		t.Fatalf("expected %v, got %v", depth-1, leaves[0].Value())
+	}
+
+	count := 0
+	for range tree.PostOrder() {
+		count++
+	}
+
+	if count != depth {
+		t.Fatalf("expected %v, got %v", depth, count)
+	}
+}